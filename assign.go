@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// userSearchDelay batches keystrokes into one user search.
+const userSearchDelay = 200 * time.Millisecond
+
+// openAssignPicker lets the user search for an assignee of the selected
+// issue. "Assign to me" and "Unassign" are always listed first.
+func (app *TUIApp) openAssignPicker(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	search := func(query string) ([]jira.User, error) {
+		return app.jiraClient.FindAssignableUsersContext(app.ctx, issue.Key, query)
+	}
+	app.showUserPicker("Assign "+issue.Key, []string{"> Assign to me", "> Unassign"}, search, func(shortcut int, user *jira.User) {
+		switch shortcut {
+		case 0:
+			app.assignCurrentUser(boardID, issue)
+		case 1:
+			app.assign(boardID, issue, nil)
+		default:
+			app.assign(boardID, issue, user)
+		}
+	})
+	return nil
+}
+
+// showUserPicker opens a picker that runs search as the user types, listing
+// the shortcuts before the users found. onSelect receives the index of the
+// picked shortcut, or -1 and the picked user. It must run on the gocui main
+// loop.
+func (app *TUIApp) showUserPicker(title string, shortcuts []string, search func(query string) ([]jira.User, error), onSelect func(shortcut int, user *jira.User)) {
+	var users []jira.User
+	var searches int64
+
+	onQuery := func(query string) {
+		current := atomic.AddInt64(&searches, 1)
+		app.setPickerItems(append(append([]string(nil), shortcuts...), "Searching..."))
+		users = nil
+
+		go func() {
+			time.Sleep(userSearchDelay)
+			if atomic.LoadInt64(&searches) != current {
+				return // Superseded by a later keystroke
+			}
+			found, err := search(query)
+
+			app.update(func(g *gocui.Gui) error {
+				if atomic.LoadInt64(&searches) != current || app.picker == nil {
+					return nil
+				}
+				items := append([]string(nil), shortcuts...)
+				switch {
+				case err != nil:
+					items = append(items, fmt.Sprintf("Search failed: %v", err))
+				case len(found) == 0:
+					items = append(items, "No matching users")
+				}
+				for _, user := range found {
+					items = append(items, userLabel(user))
+				}
+				users = found
+				app.setPickerItems(items)
+				return nil
+			})
+		}()
+	}
+
+	app.showSearchPicker(title, onQuery, func(index int) error {
+		switch {
+		case index < len(shortcuts):
+			onSelect(index, nil)
+		case index-len(shortcuts) < len(users):
+			user := users[index-len(shortcuts)]
+			onSelect(-1, &user)
+		}
+		return nil
+	})
+}
+
+func (app *TUIApp) assignToMe(g *gocui.Gui, v *gocui.View) error {
+	if boardID, issue, ok := app.selectedIssue(v); ok {
+		app.assignCurrentUser(boardID, issue)
+	}
+	return nil
+}
+
+func (app *TUIApp) unassign(g *gocui.Gui, v *gocui.View) error {
+	if boardID, issue, ok := app.selectedIssue(v); ok {
+		app.assign(boardID, issue, nil)
+	}
+	return nil
+}
+
+func (app *TUIApp) assignCurrentUser(boardID string, issue jira.Issue) {
+	go func() {
+		myself, err := app.currentUser()
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot determine the current user: %v", err)
+				return nil
+			}
+			app.assign(boardID, issue, myself)
+			return nil
+		})
+	}()
+}
+
+// assign shows the new assignee on the card right away and sends the change
+// in the background, restoring the previous assignee if Jira refuses it.
+// A nil user unassigns the issue.
+func (app *TUIApp) assign(boardID string, issue jira.Issue, user *jira.User) {
+	previous := issue.Fields.Assignee
+	var assignee *jira.Assignee
+	name := "nobody"
+	if user != nil {
+		assignee = &jira.Assignee{Name: user.Name, AccountID: user.AccountID, DisplayName: user.DisplayName}
+		name = user.DisplayName
+	}
+
+	app.updateCachedIssue(boardID, issue.Key, func(cached *jira.Issue) {
+		cached.Fields.Assignee = assignee
+	})
+	app.setStatusMessage("Assigning %s to %s...", issue.Key, name)
+
+	go func() {
+		err := app.jiraClient.AssignIssueContext(app.ctx, issue.Key, user)
+		if err != nil {
+			app.setStatusMessage("Assigning %s failed: %v", issue.Key, err)
+			app.updateCachedIssue(boardID, issue.Key, func(cached *jira.Issue) {
+				cached.Fields.Assignee = previous
+			})
+		} else {
+			app.setStatusMessage("Assigned %s to %s", issue.Key, name)
+			app.rememberOwnChange(boardID, issue.Key)
+		}
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
+
+// userLabel is a one-line description of a user for pickers.
+func userLabel(user jira.User) string {
+	id := user.Name
+	if id == "" {
+		id = user.EmailAddress
+	}
+	if id == "" {
+		return user.DisplayName
+	}
+	return fmt.Sprintf("%s (%s)", user.DisplayName, id)
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+const backlogView = "backlog"
+
+// backlogState is the backlog of a board, shown in place of its columns
+// while the board's backlog tab is open.
+type backlogState struct {
+	issues   []jira.Issue
+	loaded   bool
+	err      error
+	estimate *jira.EstimationField // Field the board estimates with, nil for none
+}
+
+// toggleBacklog switches the current board between its sprint and its
+// backlog.
+func (app *TUIApp) toggleBacklog(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	board := app.config.Boards[app.currentBoard]
+	if board.JQL != "" {
+		app.setStatusMessage("Query tabs have no backlog")
+		return nil
+	}
+
+	app.mutex.Lock()
+	_, open := app.backlogs[board.ID]
+	if open {
+		delete(app.backlogs, board.ID)
+	} else {
+		app.backlogs[board.ID] = &backlogState{}
+	}
+	app.mutex.Unlock()
+
+	if !open {
+		go app.loadBacklog(app.ctx, board.ID)
+	}
+	return nil
+}
+
+// loadBacklog fetches the backlog of a board whose backlog tab is open,
+// along with the field the board estimates issues with. It must not run on
+// the gocui main loop.
+func (app *TUIApp) loadBacklog(ctx context.Context, boardID string) {
+	app.mutex.Lock()
+	_, open := app.backlogs[boardID]
+	app.mutex.Unlock()
+	if !open {
+		return
+	}
+
+	// Estimates are left out when the configuration is not available
+	var estimate *jira.EstimationField
+	if configuration, err := app.getBoardConfiguration(ctx, boardID); err == nil && configuration.Estimation != nil {
+		estimate = configuration.Estimation.Field
+	}
+	issues, err := app.jiraClient.GetBoardBacklogContext(ctx, boardID)
+	if ctx.Err() != nil {
+		return
+	}
+	app.loadEpics(ctx, issues)
+
+	app.mutex.Lock()
+	if backlog := app.backlogs[boardID]; backlog != nil {
+		backlog.loaded = true
+		backlog.err = err
+		backlog.estimate = estimate
+		if err == nil {
+			backlog.issues = issues
+		}
+	}
+	app.mutex.Unlock()
+
+	app.update(func(g *gocui.Gui) error { return nil })
+}
+
+// layoutBacklog draws the backlog of a board over the column area. It runs
+// with app.mutex held.
+func (app *TUIApp) layoutBacklog(g *gocui.Gui, board string, backlog *backlogState, x1, y1, x2, y2 int) error {
+	v, err := g.SetView(backlogView, x1, y1, x2, y2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Highlight = true
+		v.SelBgColor = gocui.ColorDefault
+		v.SelFgColor = gocui.ColorWhite
+		v.BgColor = gocui.ColorDefault
+		v.FgColor = gocui.ColorWhite
+	}
+
+	v.Title = fmt.Sprintf("Backlog - %s (%s)", board, backlogTotal(backlog))
+	v.Clear()
+	switch {
+	case backlog.err != nil:
+		fmt.Fprintf(v, "Cannot load the backlog:\n%v\n", backlog.err)
+	case !backlog.loaded:
+		fmt.Fprintln(v, "Loading backlog...")
+	case len(backlog.issues) == 0:
+		fmt.Fprintln(v, "The backlog is empty")
+	}
+
+	width, _ := v.Size()
+	keys := make([]string, 0, len(backlog.issues))
+	for _, issue := range backlog.issues {
+		fmt.Fprintln(v, backlogLine(issue, app.issueEpic(issue), backlog.estimate, width))
+		keys = append(keys, issue.Key)
+	}
+	if backlog.err == nil {
+		app.viewIssues[backlogView] = keys
+	}
+	app.activeViews = append(app.activeViews, backlogView)
+	return nil
+}
+
+// backlogTotal sums up the backlog for the view title: the issue count and,
+// on boards with estimates, the estimated total.
+func backlogTotal(backlog *backlogState) string {
+	total := fmt.Sprintf("%d issues", len(backlog.issues))
+	if backlog.estimate == nil {
+		return total
+	}
+
+	sum, unestimated := 0.0, 0
+	for _, issue := range backlog.issues {
+		if value, ok := issue.Fields.Number(backlog.estimate.FieldID); ok {
+			sum += value
+		} else {
+			unestimated++
+		}
+	}
+	total += fmt.Sprintf(", %s %s", formatEstimate(backlog.estimate.FieldID, sum), backlog.estimate.DisplayName)
+	if unestimated > 0 {
+		total += fmt.Sprintf(", %d not estimated", unestimated)
+	}
+	return total
+}
+
+// backlogLine renders a backlog issue in one line: key, priority, estimate,
+// epic and summary.
+func backlogLine(issue jira.Issue, epic *jira.Epic, estimate *jira.EstimationField, width int) string {
+	priority := "-"
+	if issue.Fields.Priority != nil {
+		priority = issue.Fields.Priority.Name
+	}
+	points := "-"
+	if estimate != nil {
+		if value, ok := issue.Fields.Number(estimate.FieldID); ok {
+			points = formatEstimate(estimate.FieldID, value)
+		}
+	}
+	name := ""
+	if epic != nil {
+		name = epicName(epic)
+	}
+
+	line := fmt.Sprintf("%-10s %-8.8s %5s  %-16.16s %s", issue.Key, priority, points, name, issue.Fields.Summary)
+	if runes := []rune(line); width > 0 && len(runes) > width {
+		line = string(runes[:width])
+	}
+	return line
+}
+
+// formatEstimate shows time estimates, which Jira keeps in seconds, in
+// hours and any other estimate as the plain number.
+func formatEstimate(fieldID string, value float64) string {
+	if strings.HasPrefix(fieldID, "time") || strings.HasPrefix(fieldID, "aggregatetime") {
+		return strconv.FormatFloat(value/3600, 'f', -1, 64) + "h"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// backlogIssue returns an issue of a board's backlog by key. The caller
+// must hold app.mutex.
+func (app *TUIApp) backlogIssue(boardID, key string) (jira.Issue, bool) {
+	if backlog := app.backlogs[boardID]; backlog != nil {
+		for _, issue := range backlog.issues {
+			if issue.Key == key {
+				return issue, true
+			}
+		}
+	}
+	return jira.Issue{}, false
+}
+
+// openMoveToSprint lists the active and future sprints of the board and
+// moves the selected backlog issue into the chosen one.
+func (app *TUIApp) openMoveToSprint(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	app.fetchSprints(boardID, func(sprints []jira.Sprint) error {
+		open := openSprints(sprints)
+		if len(open) == 0 {
+			app.setStatusMessage("The board has no active or future sprint to move %s to", issue.Key)
+			return nil
+		}
+
+		items := make([]string, len(open))
+		for i, sprint := range open {
+			items[i] = sprintLabel(sprint)
+		}
+		app.showPicker("Move "+issue.Key+" to sprint", items, func(index int) error {
+			app.moveToSprint(boardID, issue, open[index])
+			return nil
+		})
+		return nil
+	})
+	return nil
+}
+
+// moveToSprint takes an issue off the backlog right away and moves it in
+// the background. On failure the backlog is loaded again; either way the
+// board is refreshed so an issue moved into the active sprint shows up.
+func (app *TUIApp) moveToSprint(boardID string, issue jira.Issue, sprint jira.Sprint) {
+	app.mutex.Lock()
+	if backlog := app.backlogs[boardID]; backlog != nil {
+		for i := range backlog.issues {
+			if backlog.issues[i].Key == issue.Key {
+				backlog.issues = append(backlog.issues[:i:i], backlog.issues[i+1:]...)
+				break
+			}
+		}
+	}
+	app.mutex.Unlock()
+	app.setStatusMessage("Moving %s to %s...", issue.Key, sprint.Name)
+
+	go func() {
+		err := app.jiraClient.MoveIssuesToSprintContext(app.ctx, sprint.ID, []string{issue.Key})
+		if err != nil {
+			app.setStatusMessage("Moving %s to %s failed: %v", issue.Key, sprint.Name, err)
+			app.loadBacklog(app.ctx, boardID)
+			return
+		}
+
+		app.setStatusMessage("Moved %s to %s", issue.Key, sprint.Name)
+		app.refreshBoardData(app.ctx, boardID)
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira"
+)
+
+// getBoardDetails returns the board's type and project, asking Jira on
+// first use. It must not run on the gocui main loop.
+func (app *TUIApp) getBoardDetails(ctx context.Context, boardID string) (*jira.Board, error) {
+	app.mutex.Lock()
+	board := app.boardDetails[boardID]
+	app.mutex.Unlock()
+	if board != nil {
+		return board, nil
+	}
+
+	board, err := app.jiraClient.GetBoardContext(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	app.mutex.Lock()
+	app.boardDetails[boardID] = board
+	app.mutex.Unlock()
+	return board, nil
+}
+
+// getBoardConfiguration returns the board's filter setup, asking Jira on
+// first use. It must not run on the gocui main loop.
+func (app *TUIApp) getBoardConfiguration(ctx context.Context, boardID string) (*jira.BoardConfiguration, error) {
+	app.mutex.Lock()
+	configuration := app.boardConfigurations[boardID]
+	app.mutex.Unlock()
+	if configuration != nil {
+		return configuration, nil
+	}
+
+	configuration, err := app.jiraClient.GetBoardConfigurationContext(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	app.mutex.Lock()
+	app.boardConfigurations[boardID] = configuration
+	app.mutex.Unlock()
+	return configuration, nil
+}
+
+// recentlyDone keeps done issues on Kanban boards only while they were
+// updated lately, like Jira hides completed issues after two weeks. The
+// filter of a long-running board would otherwise bring every issue ever
+// finished, with its changelog, on every refresh.
+const recentlyDone = "statusCategory != Done OR updated >= -14d"
+
+// fetchKanbanIssues loads the issues of a board without sprints: those of
+// the board's filter, narrowed by its sub-filter as on the board in Jira,
+// and done issues only while recent.
+func (app *TUIApp) fetchKanbanIssues(ctx context.Context, boardID string) ([]jira.Issue, error) {
+	configuration, err := app.getBoardConfiguration(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	jql := "(" + recentlyDone + ")"
+	if configuration.SubQuery != nil && configuration.SubQuery.Query != "" {
+		jql = "(" + configuration.SubQuery.Query + ") AND " + jql
+	}
+	return app.jiraClient.GetBoardIssuesContext(ctx, boardID, jql)
+}
+
+// loadBoardWorkflow derives the columns of a board from its configuration
+// in Jira, once per board whose workflow is set to fromBoard. Until that
+// succeeds the board is drawn with the configured workflow. It must not run
+// on the gocui main loop.
+func (app *TUIApp) loadBoardWorkflow(ctx context.Context, boardID string) {
+	app.mutex.Lock()
+	configured := app.config.BoardWorkflow(boardID)
+	loaded := app.boardWorkflows[boardID] != nil
+	statuses := app.statuses
+	app.mutex.Unlock()
+	if !configured.FromBoard || loaded {
+		return
+	}
+
+	configuration, err := app.getBoardConfiguration(ctx, boardID)
+	if err == nil && len(configuration.ColumnConfig.Columns) == 0 {
+		err = fmt.Errorf("the board has no columns")
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			app.setStatusMessage("Using the configured workflow for board %s: %v", boardID, err)
+		}
+		return
+	}
+
+	// Names only help matching issues without status IDs, go on without them
+	if statuses == nil {
+		if statuses, err = app.jiraClient.GetStatusesContext(ctx); err == nil {
+			app.mutex.Lock()
+			app.statuses = statuses
+			app.mutex.Unlock()
+		}
+	}
+
+	workflow := workflowFromBoard(configuration, statuses)
+	workflow.HiddenStatuses = configured.HiddenStatuses
+	app.mutex.Lock()
+	app.boardWorkflows[boardID] = &workflow
+	app.mutex.Unlock()
+}
+
+// workflowFromBoard turns the columns of a board configuration into a
+// workflow, in the board's order and with the board's column constraints.
+// Columns without statuses are left out as no issue can show up in them.
+func workflowFromBoard(configuration *jira.BoardConfiguration, statuses []jira.Status) config.Workflow {
+	names := make(map[string]string)
+	for _, status := range statuses {
+		names[status.ID] = status.Name
+	}
+
+	constrained := configuration.ColumnConfig.ConstraintType == "issueCount" ||
+		configuration.ColumnConfig.ConstraintType == "issueCountExclSubs"
+
+	workflow := config.Workflow{FromBoard: true, Limits: make(map[string]config.ColumnLimit)}
+	for _, column := range configuration.ColumnConfig.Columns {
+		if len(column.Statuses) == 0 {
+			continue
+		}
+
+		mapping := config.StatusMapping{Column: column.Name}
+		for _, status := range column.Statuses {
+			mapping.StatusIDs = append(mapping.StatusIDs, status.ID)
+			if name := names[status.ID]; name != "" {
+				mapping.Statuses = append(mapping.Statuses, name)
+			}
+		}
+		workflow.Columns = append(workflow.Columns, column.Name)
+		workflow.StatusMapping = append(workflow.StatusMapping, mapping)
+
+		if constrained && (column.Min > 0 || column.Max > 0) {
+			workflow.Limits[column.Name] = config.ColumnLimit{
+				Min:             column.Min,
+				Max:             column.Max,
+				ExcludeSubtasks: configuration.ColumnConfig.ConstraintType == "issueCountExclSubs",
+			}
+		}
+	}
+	return workflow
+}
+
+// workflowFor returns the workflow a board is drawn with: the one derived
+// from the board when loaded, otherwise the board's own or the global one
+// from the config. The caller must hold app.mutex.
+func (app *TUIApp) workflowFor(boardID string) config.Workflow {
+	configured := app.config.BoardWorkflow(boardID)
+	if workflow := app.boardWorkflows[boardID]; workflow != nil && configured.FromBoard {
+		return *workflow
+	}
+	return configured
+}
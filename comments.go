@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// composeComment opens the composer for a new comment on the selected issue.
+func (app *TUIApp) composeComment(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	app.showComposer("Comment on "+issue.Key, "", func(text string) {
+		go func() {
+			comment, err := app.jiraClient.AddCommentContext(app.ctx, issue.Key, text)
+			if err == nil {
+				app.updateIssueComments(boardID, issue.Key, func(comments []jira.Comment) []jira.Comment {
+					return append(comments, *comment)
+				})
+				app.setStatusMessage("Commented on %s", issue.Key)
+			}
+			app.finishComposer(err)
+		}()
+	})
+	return nil
+}
+
+// manageComments lists the user's own comments on the selected issue to
+// edit or delete one of them.
+func (app *TUIApp) manageComments(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	go func() {
+		myself, err := app.currentUser()
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot determine the current user: %v", err)
+				return nil
+			}
+
+			var own []jira.Comment
+			if issue.Fields.Comment != nil {
+				for _, comment := range issue.Fields.Comment.Comments {
+					if myself.Is(comment.Author) {
+						own = append(own, comment)
+					}
+				}
+			}
+			if len(own) == 0 {
+				app.setStatusMessage("You have no comments on %s", issue.Key)
+				return nil
+			}
+
+			items := make([]string, len(own))
+			for i, comment := range own {
+				items[i] = commentLabel(comment)
+			}
+			app.showPicker("Your comments on "+issue.Key, items, func(index int) error {
+				app.commentActions(boardID, issue.Key, own[index])
+				return nil
+			})
+			return nil
+		})
+	}()
+	return nil
+}
+
+func (app *TUIApp) commentActions(boardID, key string, comment jira.Comment) {
+	app.showPicker("Comment "+comment.ID, []string{"Edit", "Delete"}, func(index int) error {
+		if index == 0 {
+			app.editComment(boardID, key, comment)
+			return nil
+		}
+		app.confirm("Delete comment "+comment.ID+"?", func() error {
+			app.deleteComment(boardID, key, comment.ID)
+			return nil
+		})
+		return nil
+	})
+}
+
+func (app *TUIApp) editComment(boardID, key string, comment jira.Comment) {
+	app.showComposer("Edit comment on "+key, comment.Body, func(text string) {
+		go func() {
+			updated, err := app.jiraClient.UpdateCommentContext(app.ctx, key, comment.ID, text)
+			if err == nil {
+				app.updateIssueComments(boardID, key, func(comments []jira.Comment) []jira.Comment {
+					for i := range comments {
+						if comments[i].ID == comment.ID {
+							comments[i] = *updated
+						}
+					}
+					return comments
+				})
+				app.setStatusMessage("Updated comment on %s", key)
+			}
+			app.finishComposer(err)
+		}()
+	})
+}
+
+func (app *TUIApp) deleteComment(boardID, key, commentID string) {
+	app.setStatusMessage("Deleting comment on %s...", key)
+
+	go func() {
+		err := app.jiraClient.DeleteCommentContext(app.ctx, key, commentID)
+		if err != nil {
+			app.setStatusMessage("Deleting comment failed: %v", err)
+		} else {
+			app.updateIssueComments(boardID, key, func(comments []jira.Comment) []jira.Comment {
+				for i := range comments {
+					if comments[i].ID == commentID {
+						return append(comments[:i:i], comments[i+1:]...)
+					}
+				}
+				return comments
+			})
+			app.setStatusMessage("Deleted comment on %s", key)
+		}
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
+
+// currentUser returns the authenticated user, asking Jira on first use.
+// It must not run on the gocui main loop.
+func (app *TUIApp) currentUser() (*jira.User, error) {
+	app.mutex.Lock()
+	myself := app.myself
+	app.mutex.Unlock()
+	if myself != nil {
+		return myself, nil
+	}
+
+	myself, err := app.jiraClient.MyselfContext(app.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	app.mutex.Lock()
+	app.myself = myself
+	app.mutex.Unlock()
+	return myself, nil
+}
+
+// updateIssueComments changes the cached comments of an issue so the
+// activity panel shows the result before the next refresh.
+func (app *TUIApp) updateIssueComments(boardID, key string, update func([]jira.Comment) []jira.Comment) {
+	app.updateCachedIssue(boardID, key, func(issue *jira.Issue) {
+		if issue.Fields.Comment == nil {
+			issue.Fields.Comment = &jira.CommentBlock{}
+		}
+		issue.Fields.Comment.Comments = update(issue.Fields.Comment.Comments)
+	})
+}
+
+// commentLabel is a one-line summary of a comment for pickers.
+func commentLabel(comment jira.Comment) string {
+	created := comment.Created
+	if len(created) > 16 {
+		created = strings.Replace(created[:16], "T", " ", 1)
+	}
+	body := strings.Join(strings.Fields(comment.Body), " ")
+	if len([]rune(body)) > 50 {
+		body = string([]rune(body)[:50]) + "..."
+	}
+	return fmt.Sprintf("%s %s", created, body)
+}
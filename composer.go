@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+const composerView = "composer"
+
+// composerState describes a modal multi-line editor. onSubmit receives the
+// text and is responsible for closing the composer, usually through
+// finishComposer once Jira has answered, so nothing typed is lost on errors.
+type composerState struct {
+	title      string
+	initial    string
+	onSubmit   func(text string)
+	message    string // Last submission error
+	submitting bool
+	returnTo   string
+	allowEmpty bool // Empty text is submitted, as when clearing a field
+}
+
+// showComposer opens the editor. It must run on the gocui main loop.
+func (app *TUIApp) showComposer(title, initial string, onSubmit func(text string)) {
+	app.gui.DeleteView(composerView)
+	app.composer = &composerState{
+		title:    title,
+		initial:  initial,
+		onSubmit: onSubmit,
+		returnTo: app.currentViewName(composerView),
+	}
+}
+
+func (app *TUIApp) layoutComposer(g *gocui.Gui, maxX, maxY int) error {
+	if app.composer == nil {
+		return nil
+	}
+
+	x0, y0, x1, y1 := centered(maxX, maxY, maxX*2/3, maxY/2)
+	v, err := g.SetView(composerView, x0, y0, x1, y1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Editable = true
+		v.Wrap = true
+		fmt.Fprint(v, app.composer.initial)
+
+		// Continue typing at the end of the existing text
+		lines := strings.Split(app.composer.initial, "\n")
+		v.SetCursor(len([]rune(lines[len(lines)-1])), len(lines)-1)
+	}
+
+	switch {
+	case app.composer.submitting:
+		v.Title = app.composer.title + " (sending...)"
+	case app.composer.message != "":
+		v.Title = app.composer.title + " - " + app.composer.message
+	default:
+		v.Title = app.composer.title + " (Ctrl+S: send, Esc: cancel)"
+	}
+
+	g.SetViewOnTop(composerView)
+	g.SetCurrentView(composerView)
+	return nil
+}
+
+func (app *TUIApp) submitComposer(g *gocui.Gui, v *gocui.View) error {
+	if app.composer == nil || app.composer.submitting {
+		return nil
+	}
+
+	text := strings.TrimSpace(v.Buffer())
+	if text == "" && !app.composer.allowEmpty {
+		app.composer.message = "nothing to send"
+		return nil
+	}
+
+	app.composer.message = ""
+	app.composer.submitting = true
+	app.composer.onSubmit(text)
+	return nil
+}
+
+func (app *TUIApp) cancelComposer(g *gocui.Gui, v *gocui.View) error {
+	if app.composer != nil && app.composer.submitting {
+		return nil
+	}
+	app.closeComposer(g)
+	return nil
+}
+
+func (app *TUIApp) closeComposer(g *gocui.Gui) {
+	returnTo := ""
+	if app.composer != nil {
+		returnTo = app.composer.returnTo
+	}
+	app.composer = nil
+	g.DeleteView(composerView)
+	restoreFocus(g, returnTo)
+}
+
+// finishComposer reports the outcome of a submission made from a background
+// goroutine: on success the composer closes, otherwise it stays open with
+// the error in its title.
+func (app *TUIApp) finishComposer(err error) {
+	app.update(func(g *gocui.Gui) error {
+		if app.composer == nil {
+			return nil
+		}
+		app.composer.submitting = false
+
+		if err != nil {
+			app.composer.message = err.Error()
+			return nil
+		}
+		app.closeComposer(g)
+		return nil
+	})
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// createFieldOrder lists the fields offered on the create form, in order,
+// when the create screen has them. Other fields are only shown if required.
+var createFieldOrder = []string{"summary", "priority", "assignee", "duedate", "labels", "description"}
+
+// openCreateIssue asks for an issue type and then opens the create form for
+// the project of the current board.
+func (app *TUIApp) openCreateIssue(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	if app.config.Boards[app.currentBoard].JQL != "" {
+		app.setStatusMessage("Query tabs have no project to create issues in")
+		return nil
+	}
+	boardID := app.config.Boards[app.currentBoard].ID
+	app.setStatusMessage("Loading create screen...")
+
+	go func() {
+		projectKey, issueTypes, err := app.loadCreateMeta(app.ctx, boardID)
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot create issues here: %v", err)
+				return nil
+			}
+			app.setStatusMessage("")
+
+			var creatable []jira.IssueTypeMeta
+			for _, issueType := range issueTypes {
+				if !issueType.Subtask {
+					creatable = append(creatable, issueType)
+				}
+			}
+			if len(creatable) == 0 {
+				app.setStatusMessage("You cannot create issues in %s", projectKey)
+				return nil
+			}
+
+			names := make([]string, len(creatable))
+			for i, issueType := range creatable {
+				names[i] = issueType.Name
+			}
+			app.showPicker("New issue in "+projectKey, names, func(index int) error {
+				app.showCreateForm(boardID, projectKey, creatable[index])
+				return nil
+			})
+			return nil
+		})
+	}()
+	return nil
+}
+
+// loadCreateMeta returns the project of a board and its create screens,
+// caching both.
+func (app *TUIApp) loadCreateMeta(ctx context.Context, boardID string) (string, []jira.IssueTypeMeta, error) {
+	board, err := app.getBoardDetails(ctx, boardID)
+	if err != nil {
+		return "", nil, err
+	}
+	if board.Location == nil || board.Location.ProjectKey == "" {
+		return "", nil, fmt.Errorf("board %s does not belong to a project", boardID)
+	}
+	projectKey := board.Location.ProjectKey
+
+	app.mutex.Lock()
+	issueTypes, ok := app.createMeta[projectKey]
+	app.mutex.Unlock()
+	if ok {
+		return projectKey, issueTypes, nil
+	}
+
+	issueTypes, err = app.jiraClient.GetCreateMetaContext(ctx, projectKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	app.mutex.Lock()
+	app.createMeta[projectKey] = issueTypes
+	app.mutex.Unlock()
+	return projectKey, issueTypes, nil
+}
+
+func (app *TUIApp) showCreateForm(boardID, projectKey string, issueType jira.IssueTypeMeta) {
+	var ids []string
+	for _, id := range createFieldOrder {
+		if _, ok := issueType.Fields[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	var extra []string
+	for id, field := range issueType.Fields {
+		if field.Required && !field.HasDefaultValue && !contains(ids, id) && id != "project" && id != "issuetype" {
+			extra = append(extra, id)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool {
+		return issueType.Fields[extra[i]].Name < issueType.Fields[extra[j]].Name
+	})
+	ids = append(ids, extra...)
+
+	fields := make([]*formField, 0, len(ids))
+	for _, id := range ids {
+		fields = append(fields, metaFormField(id, issueType.Fields[id], ""))
+	}
+	searchUsers(fields, issueType.Fields, func(query string) ([]jira.User, error) {
+		return app.jiraClient.FindProjectAssignableUsersContext(app.ctx, projectKey, query)
+	})
+
+	app.showForm(fmt.Sprintf("New %s in %s", issueType.Name, projectKey), fields, func(values map[string]string) {
+		payload, errors := app.form.fieldValues(issueType.Fields, values, false)
+		if !app.setFormErrors(errors) {
+			return
+		}
+		payload["project"] = map[string]string{"key": projectKey}
+		payload["issuetype"] = map[string]string{"id": issueType.ID}
+
+		app.form.submitting = true
+		go app.createIssue(boardID, payload)
+	})
+}
+
+// createIssue creates the issue, adds it to the board's active sprint unless
+// the board is a Kanban board, and shows it on the board right away.
+func (app *TUIApp) createIssue(boardID string, payload map[string]interface{}) {
+	created, err := app.jiraClient.CreateIssueContext(app.ctx, payload)
+	if err != nil {
+		app.finishForm(err, nil)
+		return
+	}
+
+	app.mutex.Lock()
+	sprints := app.boardSprints[boardID]
+	app.mutex.Unlock()
+
+	message := fmt.Sprintf("Created %s", created.Key)
+	switch board, _ := app.getBoardDetails(app.ctx, boardID); {
+	case board != nil && board.Type == "kanban":
+		// Kanban boards show their filter's issues, no sprint to add it to
+		app.showCreatedIssue(boardID, created.Key)
+	case len(sprints) == 0:
+		message += " in the backlog, the board has no active sprint"
+	default:
+		if err := app.jiraClient.MoveIssuesToSprintContext(app.ctx, sprints[0].ID, []string{created.Key}); err != nil {
+			message += fmt.Sprintf(" but could not add it to %s: %v", sprints[0].Name, err)
+			break
+		}
+		app.showCreatedIssue(boardID, created.Key)
+		message += " in " + sprints[0].Name
+	}
+
+	app.finishForm(nil, func() {
+		app.setStatusMessage("%s", message)
+	})
+}
+
+// showCreatedIssue adds a new issue to the board cache so it shows before
+// the next refresh.
+func (app *TUIApp) showCreatedIssue(boardID, key string) {
+	issue, err := app.jiraClient.SearchIssueContext(app.ctx, key)
+	if err != nil {
+		return
+	}
+	app.mutex.Lock()
+	app.boardData[boardID] = append(app.boardData[boardID], *issue)
+	app.mutex.Unlock()
+	app.rememberOwnChange(boardID, key)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// cardFilter narrows the cards of a board down by custom field values, e.g.
+// "team=Core points>=3 flagged". Every term has to match.
+type cardFilter struct {
+	text  string
+	terms []filterTerm
+}
+
+// filterTerm compares a custom field with a value. op is "=", "!=", "~"
+// (contains), "<", "<=", ">" or ">=", "set" for a bare field name and
+// "unset" for a name with a leading "!".
+type filterTerm struct {
+	field  config.CustomField
+	op     string
+	value  string
+	number float64
+}
+
+// filterOps are tried in order, so two-character operators win.
+var filterOps = []string{"!=", "<=", ">=", "=", "~", "<", ">"}
+
+// parseCardFilter reads a filter typed by the user against the configured
+// custom fields.
+func parseCardFilter(text string, cfg *config.Config) (*cardFilter, error) {
+	filter := &cardFilter{text: strings.TrimSpace(text)}
+	for _, word := range strings.Fields(text) {
+		term := filterTerm{op: "set"}
+		name := word
+		for _, op := range filterOps {
+			if i := strings.Index(word, op); i > 0 {
+				name, term.op, term.value = word[:i], op, word[i+len(op):]
+				break
+			}
+		}
+		if term.op == "set" && strings.HasPrefix(name, "!") {
+			name, term.op = name[1:], "unset"
+		}
+
+		field, ok := cfg.CustomField(name)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a custom field", name)
+		}
+		term.field = field
+		switch term.op {
+		case "<", "<=", ">", ">=":
+			number, err := strconv.ParseFloat(term.value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s%s needs a number", name, term.op)
+			}
+			term.number = number
+		}
+		filter.terms = append(filter.terms, term)
+	}
+	return filter, nil
+}
+
+// matches reports whether an issue passes every term of the filter. Values
+// of multi-value fields match "=" and "!=" one by one. The caller must hold
+// app.mutex.
+func (app *TUIApp) matches(filter *cardFilter, issue jira.Issue) bool {
+	for _, term := range filter.terms {
+		id := app.customFieldID(term.field)
+		text, set := issue.Fields.Text(id)
+
+		var ok bool
+		switch term.op {
+		case "set":
+			ok = set
+		case "unset":
+			ok = !set
+		case "=", "!=":
+			found := false
+			for _, value := range strings.Split(text, ", ") {
+				if set && strings.EqualFold(value, term.value) {
+					found = true
+				}
+			}
+			ok = found == (term.op == "=")
+		case "~":
+			ok = strings.Contains(strings.ToLower(text), strings.ToLower(term.value))
+		default:
+			number, isNumber := issue.Fields.Number(id)
+			ok = isNumber && compare(number, term.op, term.number)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	}
+	return a >= b
+}
+
+// customFieldID returns the ID of a configured custom field, as configured
+// or found by name among the fields of the instance, "" while unknown. The
+// caller must hold app.mutex.
+func (app *TUIApp) customFieldID(field config.CustomField) string {
+	if field.ID != "" {
+		return field.ID
+	}
+	return jira.FieldID(app.fields, field.LookupName())
+}
+
+// customValues renders the set custom fields of an issue as "name: value",
+// only those shown on cards when onCard is set. The caller must hold
+// app.mutex.
+func (app *TUIApp) customValues(issue jira.Issue, onCard bool) []string {
+	var values []string
+	for _, field := range app.config.CustomFields {
+		if onCard && !field.Card {
+			continue
+		}
+		if text, ok := issue.Fields.Text(app.customFieldID(field)); ok {
+			values = append(values, field.Name+": "+text)
+		}
+	}
+	return values
+}
+
+// filterCards applies the card filter of a board and the card order to
+// cards. The caller must hold app.mutex.
+func (app *TUIApp) filterCards(boardID string, cards []jira.Issue) []jira.Issue {
+	if filter := app.cardFilters[boardID]; filter != nil {
+		matching := make([]jira.Issue, 0, len(cards))
+		for _, issue := range cards {
+			if app.matches(filter, issue) {
+				matching = append(matching, issue)
+			}
+		}
+		cards = matching
+	}
+	if app.cardSort == "" {
+		return cards
+	}
+
+	field, ok := app.config.CustomField(strings.TrimPrefix(app.cardSort, "-"))
+	if !ok {
+		return cards
+	}
+	descending := strings.HasPrefix(app.cardSort, "-")
+	id := app.customFieldID(field)
+	sorted := append([]jira.Issue(nil), cards...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Fields, sorted[j].Fields
+		textA, setA := a.Text(id)
+		textB, setB := b.Text(id)
+		if !setA || !setB {
+			// Cards without a value go last either way
+			return setA && !setB
+		}
+		if numberA, ok := a.Number(id); ok {
+			if numberB, ok := b.Number(id); ok && numberA != numberB {
+				return (numberA < numberB) != descending
+			}
+		}
+		textA, textB = strings.ToLower(textA), strings.ToLower(textB)
+		if textA == textB {
+			return false
+		}
+		return (textA < textB) != descending
+	})
+	return sorted
+}
+
+// openCardFilter asks for the card filter of the current board; an empty
+// filter shows every card again.
+func (app *TUIApp) openCardFilter(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	if len(app.config.CustomFields) == 0 {
+		app.setStatusMessage("No custom fields configured to filter by")
+		return nil
+	}
+	boardID := app.config.Boards[app.currentBoard].ID
+
+	app.mutex.Lock()
+	initial := ""
+	if filter := app.cardFilters[boardID]; filter != nil {
+		initial = filter.text
+	}
+	app.mutex.Unlock()
+
+	app.showPrompt("Filter cards (e.g. team=Core points>=3 flagged)", initial, false, func(text string) error {
+		filter, err := parseCardFilter(text, app.config)
+		if err != nil {
+			app.setStatusMessage("Invalid filter: %v", err)
+			return nil
+		}
+
+		app.mutex.Lock()
+		if len(filter.terms) == 0 {
+			delete(app.cardFilters, boardID)
+		} else {
+			app.cardFilters[boardID] = filter
+		}
+		app.mutex.Unlock()
+		return nil
+	})
+	return nil
+}
+
+// openCardSort picks the custom field the cards of every column are ordered
+// by.
+func (app *TUIApp) openCardSort(g *gocui.Gui, v *gocui.View) error {
+	if len(app.config.CustomFields) == 0 {
+		app.setStatusMessage("No custom fields configured to sort by")
+		return nil
+	}
+
+	items := []string{"Board order"}
+	orders := []string{""}
+	for _, field := range app.config.CustomFields {
+		items = append(items, field.Name+" ascending", field.Name+" descending")
+		orders = append(orders, field.Name, "-"+field.Name)
+	}
+	app.showPicker("Sort cards by", items, func(index int) error {
+		app.mutex.Lock()
+		app.cardSort = orders[index]
+		app.mutex.Unlock()
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	"jira-boards-tui/pkg/config"
+)
+
+func TestParseCardFilter(t *testing.T) {
+	cfg := &config.Config{CustomFields: []config.CustomField{
+		{Name: "team"},
+		{Name: "points"},
+		{Name: "flagged"},
+	}}
+
+	type term struct {
+		field, op, value string
+		number           float64
+	}
+	tests := []struct {
+		text    string
+		want    []term
+		wantErr bool
+	}{
+		{text: "", want: nil},
+		{text: "team=Core", want: []term{{"team", "=", "Core", 0}}},
+		{text: "team!=Core", want: []term{{"team", "!=", "Core", 0}}},
+		{text: "team~co", want: []term{{"team", "~", "co", 0}}},
+		{text: "points>=3", want: []term{{"points", ">=", "3", 3}}},
+		{text: "points<2.5", want: []term{{"points", "<", "2.5", 2.5}}},
+		{text: "flagged", want: []term{{"flagged", "set", "", 0}}},
+		{text: "!flagged", want: []term{{"flagged", "unset", "", 0}}},
+		{text: "TEAM=Core", want: []term{{"team", "=", "Core", 0}}},
+		{text: "  team=Core   points>3 flagged ", want: []term{
+			{"team", "=", "Core", 0},
+			{"points", ">", "3", 3},
+			{"flagged", "set", "", 0},
+		}},
+		{text: "points>=many", wantErr: true},
+		{text: "owner=alice", wantErr: true},
+		{text: "=Core", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			filter, err := parseCardFilter(tt.text, cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCardFilter(%q) succeeded, want an error", tt.text)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCardFilter(%q): %v", tt.text, err)
+			}
+
+			var got []term
+			for _, parsed := range filter.terms {
+				got = append(got, term{parsed.field.Name, parsed.op, parsed.value, parsed.number})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got terms %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("term %d is %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
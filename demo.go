@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/demo"
+	"jira-boards-tui/pkg/jira"
+	"jira-boards-tui/pkg/jira/jiratest"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	demoRefreshInterval = 5 // seconds
+	demoMutationEvery   = 3 * time.Second
+)
+
+// runDemo starts a simulated Jira on a local port and runs the TUI against
+// it through the regular jira.Client, so no credentials are needed.
+func runDemo() error {
+	sim := demo.NewSimulator(time.Now().UnixNano())
+	server := jiratest.NewServer(sim.Store())
+	defer server.Close()
+
+	cfg := config.Default()
+	cfg.Boards = sim.Boards()
+	cfg.JiraURL = server.URL
+	cfg.RefreshInterval = demoRefreshInterval
+	cfg.Workflow.FromBoard = true
+	// Found by name among the fields of the simulated instance
+	cfg.CustomFields = []config.CustomField{
+		{Name: "points", JiraName: "Story Points", Card: true, Sum: true},
+		{Name: "team", Card: true},
+		{Name: "flagged", Card: true},
+	}
+
+	creds := credentials{authType: "basic", username: "demo", password: "demo"}
+	auth, err := creds.authenticator(cfg.JiraURL)
+	if err != nil {
+		return err
+	}
+	client := jira.NewClientWithAuth(cfg.JiraURL, auth)
+	configureClient(client, cfg)
+
+	// Start every demo from a clean slate instead of the real state file
+	stateFile := filepath.Join(os.TempDir(), "jira-boards-tui-demo-state.json")
+	os.Remove(stateFile)
+
+	app, err := initTUIApp(cfg, client, creds, stateFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sim.Run(ctx, demoMutationEvery)
+
+	return app.Run()
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+const dependenciesView = "dependencies"
+
+// dependencyState describes the open dependency view of a board. The graph
+// is drawn from the board cache on every layout, so it follows refreshes.
+type dependencyState struct {
+	boardID   string
+	boardName string
+	returnTo  string
+}
+
+// dependencyNode is an issue of the dependency graph, on the board or linked
+// from an issue on it.
+type dependencyNode struct {
+	key     string
+	summary string
+	status  jira.Status
+	onBoard bool
+	board   string // Other board showing the issue, "" when none does
+}
+
+// dependencyGraph holds the blocking links of the issues of a board, both
+// ways, by issue key.
+type dependencyGraph struct {
+	nodes     map[string]dependencyNode
+	blocks    map[string][]string
+	blockedBy map[string][]string
+}
+
+// openDependencies shows the blocking chains of the current board. Like the
+// other overlays, app.dependencies is only replaced on the gocui main loop.
+func (app *TUIApp) openDependencies(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	board := app.config.Boards[app.currentBoard]
+
+	g.DeleteView(dependenciesView)
+	app.dependencies = &dependencyState{
+		boardID:   board.ID,
+		boardName: board.Name,
+		returnTo:  app.currentViewName(dependenciesView),
+	}
+	return nil
+}
+
+func (app *TUIApp) closeDependencies(g *gocui.Gui, v *gocui.View) error {
+	returnTo := ""
+	if app.dependencies != nil {
+		returnTo = app.dependencies.returnTo
+	}
+	app.dependencies = nil
+
+	g.DeleteView(dependenciesView)
+	restoreFocus(g, returnTo)
+	return nil
+}
+
+// layoutDependencies draws the dependency view over the board. It runs with
+// app.mutex held.
+func (app *TUIApp) layoutDependencies(g *gocui.Gui, maxX, maxY int) error {
+	d := app.dependencies
+	if d == nil {
+		return nil
+	}
+
+	v, err := g.SetView(dependenciesView, 0, 3, maxX-1, maxY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = false
+	}
+	v.Title = "Dependencies - " + d.boardName + " (j/k: scroll, Esc: close)"
+
+	v.Clear()
+	if _, loaded := app.boardData[d.boardID]; loaded {
+		renderDependencies(v, app.dependencyGraph(d.boardID))
+	} else {
+		fmt.Fprintln(v, "Loading board...")
+	}
+
+	g.SetViewOnTop(dependenciesView)
+	g.SetCurrentView(dependenciesView)
+	return nil
+}
+
+// dependencyGraph collects the blocking links of the issues on a board.
+// Linked issues off the board are named after the other board showing
+// them, if any. The caller must hold app.mutex.
+func (app *TUIApp) dependencyGraph(boardID string) *dependencyGraph {
+	graph := &dependencyGraph{
+		nodes:     make(map[string]dependencyNode),
+		blocks:    make(map[string][]string),
+		blockedBy: make(map[string][]string),
+	}
+	issues := app.boardData[boardID]
+	for _, issue := range issues {
+		graph.nodes[issue.Key] = dependencyNode{
+			key:     issue.Key,
+			summary: issue.Fields.Summary,
+			status:  issue.Fields.Status,
+			onBoard: true,
+		}
+	}
+
+	add := func(ref jira.IssueRef) {
+		if _, ok := graph.nodes[ref.Key]; !ok {
+			graph.nodes[ref.Key] = dependencyNode{
+				key:     ref.Key,
+				summary: ref.Fields.Summary,
+				status:  ref.Fields.Status,
+				board:   app.issueBoard(ref.Key, boardID),
+			}
+		}
+	}
+	linked := make(map[[2]string]bool)
+	link := func(from, to string) {
+		if linked[[2]string{from, to}] {
+			return
+		}
+		linked[[2]string{from, to}] = true
+		graph.blocks[from] = append(graph.blocks[from], to)
+		graph.blockedBy[to] = append(graph.blockedBy[to], from)
+	}
+	for _, issue := range issues {
+		for _, ref := range issue.Fields.BlockedBy() {
+			add(ref)
+			link(ref.Key, issue.Key)
+		}
+		for _, ref := range issue.Fields.Blocking() {
+			add(ref)
+			link(issue.Key, ref.Key)
+		}
+	}
+
+	for _, keys := range graph.blocks {
+		sort.Slice(keys, func(i, j int) bool { return issueKeyLess(keys[i], keys[j]) })
+	}
+	return graph
+}
+
+// issueBoard returns the name of the first board other than boardID whose
+// cached issues include key, "" when none does. The caller must hold
+// app.mutex.
+func (app *TUIApp) issueBoard(key, boardID string) string {
+	for _, board := range app.config.Boards {
+		if board.ID == boardID {
+			continue
+		}
+		for _, issue := range app.boardData[board.ID] {
+			if issue.Key == key {
+				return board.Name
+			}
+		}
+	}
+	return ""
+}
+
+// flags reports whether an issue on the board is blocked by an issue that is
+// not done, and whether it is blocked by an issue off the board.
+func (graph *dependencyGraph) flags(key string) (open, offBoard bool) {
+	for _, blocker := range graph.blockedBy[key] {
+		node := graph.nodes[blocker]
+		if !node.status.Done() {
+			open = true
+		}
+		if !node.onBoard {
+			offBoard = true
+		}
+	}
+	return open, offBoard
+}
+
+// renderDependencies draws the blocking chains as trees, each issue above
+// the issues it blocks. Chains start at the issues nothing blocks; issues
+// reached again, as in cycles, are drawn once and referred to after.
+func renderDependencies(v *gocui.View, graph *dependencyGraph) {
+	var roots, rest []string
+	blockedOpen, blockedOff := 0, 0
+	for key, node := range graph.nodes {
+		if len(graph.blocks[key]) > 0 {
+			if len(graph.blockedBy[key]) == 0 {
+				roots = append(roots, key)
+			} else {
+				rest = append(rest, key)
+			}
+		}
+		if node.onBoard {
+			open, offBoard := graph.flags(key)
+			if open {
+				blockedOpen++
+			}
+			if offBoard {
+				blockedOff++
+			}
+		}
+	}
+	if len(roots)+len(rest) == 0 {
+		fmt.Fprintln(v, "No blocking links on this board")
+		return
+	}
+	sort.Slice(roots, func(i, j int) bool { return issueKeyLess(roots[i], roots[j]) })
+	sort.Slice(rest, func(i, j int) bool { return issueKeyLess(rest[i], rest[j]) })
+
+	fmt.Fprintf(v, "Blocked cards: %d waiting on open issues, %d on issues off this board\n", blockedOpen, blockedOff)
+	fmt.Fprintln(v, "\033[31mBLOCKED\033[0m: waits on an issue not done   \033[33mOFF BOARD\033[0m: waits on an issue from elsewhere")
+
+	drawn := make(map[string]bool)
+	for _, key := range append(roots, rest...) {
+		if drawn[key] {
+			continue
+		}
+		fmt.Fprintln(v)
+		graph.render(v, key, "", "", drawn)
+	}
+}
+
+// render draws key and, below it, the chains of the issues it blocks.
+func (graph *dependencyGraph) render(v *gocui.View, key, indent, branch string, drawn map[string]bool) {
+	line := branch + graph.nodeLine(key)
+	if drawn[key] {
+		if len(graph.blocks[key]) > 0 {
+			line += " (see above)"
+		}
+		fmt.Fprintln(v, line)
+		return
+	}
+	fmt.Fprintln(v, line)
+	drawn[key] = true
+
+	blocks := graph.blocks[key]
+	for i, blocked := range blocks {
+		if i == len(blocks)-1 {
+			graph.render(v, blocked, indent+"    ", indent+"└─> ", drawn)
+		} else {
+			graph.render(v, blocked, indent+"│   ", indent+"├─> ", drawn)
+		}
+	}
+}
+
+// nodeLine describes an issue of the graph with its flags.
+func (graph *dependencyGraph) nodeLine(key string) string {
+	node := graph.nodes[key]
+	summary := []rune(node.summary)
+	if len(summary) > 40 {
+		summary = append(summary[:40], []rune("...")...)
+	}
+	line := refLine(node.key, string(summary), node.status)
+
+	switch {
+	case node.onBoard:
+	case node.board != "":
+		line += " (on " + node.board + ")"
+	default:
+		line += " (not on a board)"
+	}
+	open, offBoard := graph.flags(key)
+	if open {
+		line += " \033[31mBLOCKED\033[0m"
+	}
+	if offBoard {
+		line += " \033[33mOFF BOARD\033[0m"
+	}
+	return line
+}
+
+// blockedSummary is the link indicator on the card of an issue waiting on
+// other issues, such as "blocked by ABC-12 +1", "" for issues whose blockers
+// are all done.
+func blockedSummary(issue jira.Issue) string {
+	var keys []string
+	for _, blocker := range issue.Fields.BlockedBy() {
+		if !blocker.Fields.Status.Done() {
+			keys = append(keys, blocker.Key)
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return ""
+	case 1:
+		return "blocked by " + keys[0]
+	}
+	sort.Slice(keys, func(i, j int) bool { return issueKeyLess(keys[i], keys[j]) })
+	return fmt.Sprintf("blocked by %s +%d", keys[0], len(keys)-1)
+}
+
+// renderLinks lists the linked issues in the detail view, as the links read
+// from the issue: "is blocked by ABC-12 ...".
+func renderLinks(v *gocui.View, issue *jira.Issue, width int) {
+	links := issue.Fields.IssueLinks
+	if len(links) == 0 {
+		return
+	}
+
+	detailSection(v, fmt.Sprintf("Links (%d)", len(links)), width)
+	for _, link := range links {
+		relation, ref := link.Describe()
+		if ref == nil {
+			continue
+		}
+		line := relation + "  " + refLine(ref.Key, ref.Fields.Summary, ref.Fields.Status)
+		if link.Type.IsBlocking() && link.InwardIssue != nil && !ref.Fields.Status.Done() {
+			line = "\033[31m" + line + "\033[0m"
+		}
+		fmt.Fprintln(v, line)
+	}
+}
+
+// issueKeyLess orders issue keys by project, then by number.
+func issueKeyLess(a, b string) bool {
+	projectA, numberA, _ := strings.Cut(a, "-")
+	projectB, numberB, _ := strings.Cut(b, "-")
+	if projectA != projectB {
+		return projectA < projectB
+	}
+	if len(numberA) != len(numberB) {
+		return len(numberA) < len(numberB)
+	}
+	return numberA < numberB
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+const detailView = "detail"
+
+// detailState describes the open issue detail view. Fields and comments
+// come from the board cache so changes made from the TUI show right away,
+// the changelog from GetIssueHistory, which is fetched again whenever a
+// refresh brings a newer version of the issue.
+type detailState struct {
+	boardID  string
+	key      string
+	issue    *jira.Issue // Last GetIssueHistory result, nil until loaded
+	seen     string      // Updated timestamp of the cached issue the last fetch was for
+	loading  bool
+	err      error
+	returnTo string
+}
+
+// openDetail shows the selected issue full screen. Like the other overlays,
+// app.detail is only replaced on the gocui main loop.
+func (app *TUIApp) openDetail(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	g.DeleteView(detailView)
+	app.detail = &detailState{
+		boardID:  boardID,
+		key:      issue.Key,
+		returnTo: app.currentViewName(detailView),
+	}
+	return nil
+}
+
+func (app *TUIApp) closeDetail(g *gocui.Gui, v *gocui.View) error {
+	returnTo := ""
+	if app.detail != nil {
+		returnTo = app.detail.returnTo
+	}
+	app.detail = nil
+
+	g.DeleteView(detailView)
+	restoreFocus(g, returnTo)
+	return nil
+}
+
+// loadDetailHistory fetches the changelog of the open issue. The caller
+// must hold app.mutex.
+func (app *TUIApp) loadDetailHistory(d *detailState, seen string) {
+	d.loading = true
+	d.seen = seen
+
+	go func() {
+		issue, err := app.jiraClient.GetIssueHistoryContext(app.ctx, d.key)
+
+		app.mutex.Lock()
+		d.loading = false
+		d.err = err
+		if err == nil {
+			d.issue = issue
+		}
+		app.mutex.Unlock()
+
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
+
+// layoutDetail draws the detail view over the board. It runs with
+// app.mutex held.
+func (app *TUIApp) layoutDetail(g *gocui.Gui, maxX, maxY int) error {
+	d := app.detail
+	if d == nil {
+		return nil
+	}
+
+	var cached *jira.Issue
+	for i := range app.boardData[d.boardID] {
+		if app.boardData[d.boardID][i].Key == d.key {
+			cached = &app.boardData[d.boardID][i]
+		}
+	}
+
+	// Fetch on open and whenever a refresh saw the issue change
+	seen := d.seen
+	if cached != nil {
+		seen = cached.Fields.Updated
+	}
+	if !d.loading && (d.issue == nil && d.err == nil || seen != d.seen) {
+		app.loadDetailHistory(d, seen)
+	}
+
+	v, err := g.SetView(detailView, 0, 3, maxX-1, maxY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = false
+	}
+	v.Title = d.key + " (j/k: scroll, Esc: close)"
+	if d.loading {
+		v.Title = d.key + " (refreshing...)"
+	}
+
+	issue := cached
+	if issue == nil {
+		issue = d.issue
+	}
+	width, _ := v.Size()
+	v.Clear()
+	switch {
+	case issue != nil:
+		renderDetail(v, issue, d.issue, app.issueEpic(*issue), app.customValues(*issue, false), width)
+	case d.err != nil:
+		fmt.Fprintf(v, "Cannot load %s:\n%v\n", d.key, d.err)
+	default:
+		fmt.Fprintf(v, "Loading %s...\n", d.key)
+	}
+	if d.err != nil && issue != nil {
+		fmt.Fprintf(v, "\n\033[31mCannot load the history of %s: %v\033[0m\n", d.key, d.err)
+	}
+
+	g.SetViewOnTop(detailView)
+	g.SetCurrentView(detailView)
+	return nil
+}
+
+// renderDetail writes the issue's fields, comments and changelog. history
+// provides the changelog when loaded, otherwise the one fetched with the
+// board is shown. epic is nil for issues without an epic, custom holds the
+// set custom fields as "name: value".
+func renderDetail(v *gocui.View, issue, history *jira.Issue, epic *jira.Epic, custom []string, width int) {
+	f := issue.Fields
+	fmt.Fprintf(v, "\033[1m%s  %s\033[0m\n\n", issue.Key, f.Summary)
+
+	name := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	issueType, priority, assignee, reporter := "", "", "Unassigned", ""
+	if f.IssueType != nil {
+		issueType = f.IssueType.Name
+	}
+	if f.Priority != nil {
+		priority = f.Priority.Name
+	}
+	if f.Assignee != nil {
+		assignee = f.Assignee.DisplayName
+	}
+	if f.Reporter != nil {
+		reporter = f.Reporter.DisplayName
+	}
+	fmt.Fprintf(v, "Type: %s   Status: %s   Priority: %s\n", name(issueType), name(f.Status.Name), name(priority))
+	fmt.Fprintf(v, "Assignee: %s   Reporter: %s\n", assignee, name(reporter))
+	fmt.Fprintf(v, "Due: %s   Labels: %s\n", name(f.DueDate), name(strings.Join(f.Labels, ", ")))
+	if epic != nil {
+		fmt.Fprintf(v, "Epic: %s (%s)\n", epicName(epic), epic.Key)
+	}
+	if len(custom) > 0 {
+		fmt.Fprintln(v, strings.Join(custom, "   "))
+	}
+	fmt.Fprintf(v, "Created: %s   Updated: %s\n", shortTime(f.Created), shortTime(f.Updated))
+
+	renderHierarchy(v, issue, width)
+	renderLinks(v, issue, width)
+
+	detailSection(v, "Description", width)
+	if strings.TrimSpace(f.Description) == "" {
+		fmt.Fprintln(v, "No description")
+	}
+	for _, line := range wrapText(f.Description, width) {
+		fmt.Fprintln(v, line)
+	}
+
+	var comments []jira.Comment
+	if f.Comment != nil {
+		comments = f.Comment.Comments
+	}
+	detailSection(v, fmt.Sprintf("Comments (%d)", len(comments)), width)
+	for _, comment := range comments {
+		fmt.Fprintf(v, "[%s] %s\n", shortTime(comment.Created), comment.Author.DisplayName)
+		for _, line := range wrapText(comment.Body, width-2) {
+			fmt.Fprintln(v, "  "+line)
+		}
+	}
+
+	changelog := issue.Changelog
+	if history != nil && history.Changelog != nil {
+		changelog = history.Changelog
+	}
+	var histories []jira.History
+	if changelog != nil {
+		histories = changelog.Histories
+	}
+	detailSection(v, fmt.Sprintf("History (%d)", len(histories)), width)
+	for _, history := range histories {
+		fmt.Fprintf(v, "[%s] %s\n", shortTime(history.Created), history.Author.DisplayName)
+		for _, item := range history.Items {
+			line := fmt.Sprintf("%s: %s → %s", item.Field, name(item.FromString), name(item.ToString))
+			for _, wrapped := range wrapText(line, width-2) {
+				fmt.Fprintln(v, "  "+wrapped)
+			}
+		}
+	}
+}
+
+func detailSection(v *gocui.View, title string, width int) {
+	fmt.Fprintf(v, "\n%s\n%s\n", title, strings.Repeat("-", min(len(title), width)))
+}
+
+// shortTime trims a Jira timestamp to minutes, "2006-01-02 15:04".
+func shortTime(timestamp string) string {
+	if len(timestamp) >= 16 {
+		return strings.Replace(timestamp[:16], "T", " ", 1)
+	}
+	return timestamp
+}
+
+// wrapText breaks text into lines of at most width runes, at spaces where
+// possible. The detail view wraps itself so its line count is known for
+// scrolling.
+func wrapText(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := []rune{}
+		for _, word := range strings.Fields(paragraph) {
+			runes := []rune(word)
+			for len(runes) > width {
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+					line = line[:0]
+				}
+				lines = append(lines, string(runes[:width]))
+				runes = runes[width:]
+			}
+			if len(line) > 0 && len(line)+1+len(runes) > width {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			if len(line) > 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, runes...)
+		}
+		lines = append(lines, string(line))
+	}
+	// Drop the trailing blank line of text ending in a newline
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// scrollDetail moves the detail view by lines, keeping the last line at the
+// bottom of the view at most.
+func (app *TUIApp) scrollDetail(v *gocui.View, lines int) {
+	_, height := v.Size()
+	_, oy := v.Origin()
+	last := len(v.BufferLines()) - height
+	oy += lines
+	if oy > last {
+		oy = last
+	}
+	if oy < 0 {
+		oy = 0
+	}
+	v.SetOrigin(0, oy)
+}
+
+func (app *TUIApp) detailDown(g *gocui.Gui, v *gocui.View) error {
+	app.scrollDetail(v, 1)
+	return nil
+}
+
+func (app *TUIApp) detailUp(g *gocui.Gui, v *gocui.View) error {
+	app.scrollDetail(v, -1)
+	return nil
+}
+
+func (app *TUIApp) detailPageDown(g *gocui.Gui, v *gocui.View) error {
+	_, height := v.Size()
+	app.scrollDetail(v, height-1)
+	return nil
+}
+
+func (app *TUIApp) detailPageUp(g *gocui.Gui, v *gocui.View) error {
+	_, height := v.Size()
+	app.scrollDetail(v, -(height - 1))
+	return nil
+}
+
+func (app *TUIApp) detailTop(g *gocui.Gui, v *gocui.View) error {
+	v.SetOrigin(0, 0)
+	return nil
+}
+
+func (app *TUIApp) detailBottom(g *gocui.Gui, v *gocui.View) error {
+	_, height := v.Size()
+	app.scrollDetail(v, len(v.BufferLines())+height)
+	return nil
+}
+
+// detailIssue returns the issue of the detail view from the board cache, or
+// as last fetched when it has left the board.
+func (app *TUIApp) detailIssue() (string, jira.Issue, bool) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	d := app.detail
+	for _, issue := range app.boardData[d.boardID] {
+		if issue.Key == d.key {
+			return d.boardID, issue, true
+		}
+	}
+	if d.issue != nil {
+		return d.boardID, *d.issue, true
+	}
+	return "", jira.Issue{}, false
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// editFieldOrder lists the fields offered on the edit form, in order, when
+// the issue's edit screen has them.
+var editFieldOrder = []string{"summary", "priority", "duedate", "labels", "description"}
+
+// editIssue loads the edit screen of the selected issue and opens a form for
+// the fields that can be changed.
+func (app *TUIApp) editIssue(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+	app.setStatusMessage("Loading edit screen for %s...", issue.Key)
+
+	go func() {
+		meta, err := app.jiraClient.GetEditMetaContext(app.ctx, issue.Key)
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot edit %s: %v", issue.Key, err)
+				return nil
+			}
+			app.setStatusMessage("")
+			app.showEditForm(boardID, issue, meta)
+			return nil
+		})
+	}()
+	return nil
+}
+
+func (app *TUIApp) showEditForm(boardID string, issue jira.Issue, meta map[string]jira.FieldMeta) {
+	initial := map[string]string{
+		"summary":     issue.Fields.Summary,
+		"duedate":     issue.Fields.DueDate,
+		"labels":      strings.Join(issue.Fields.Labels, ", "),
+		"description": issue.Fields.Description,
+	}
+	if issue.Fields.Priority != nil {
+		initial["priority"] = issue.Fields.Priority.Name
+	}
+
+	var fields []*formField
+	for _, id := range editFieldOrder {
+		if field, ok := meta[id]; ok {
+			fields = append(fields, metaFormField(id, field, initial[id]))
+		}
+	}
+	if len(fields) == 0 {
+		app.setStatusMessage("You cannot edit %s", issue.Key)
+		return
+	}
+
+	app.showForm("Edit "+issue.Key, fields, func(values map[string]string) {
+		// Only send what changed, so fields edited elsewhere meanwhile are kept
+		changed := make(map[string]string)
+		for id, value := range values {
+			if strings.TrimSpace(value) != strings.TrimSpace(initial[id]) {
+				changed[id] = value
+			}
+		}
+		if len(changed) == 0 {
+			app.closeForm(app.gui)
+			return
+		}
+
+		payload, errors := app.form.fieldValues(meta, changed, true)
+		if !app.setFormErrors(errors) {
+			return
+		}
+
+		app.form.submitting = true
+		go app.updateIssue(boardID, issue.Key, payload)
+	})
+}
+
+// updateIssue sends the changed fields and replaces the card with Jira's
+// version of the issue.
+func (app *TUIApp) updateIssue(boardID, key string, payload map[string]interface{}) {
+	if err := app.jiraClient.UpdateIssueContext(app.ctx, key, payload); err != nil {
+		app.finishForm(err, nil)
+		return
+	}
+
+	if updated, err := app.jiraClient.SearchIssueContext(app.ctx, key); err == nil {
+		app.updateCachedIssue(boardID, key, func(cached *jira.Issue) {
+			*cached = *updated
+		})
+		app.rememberOwnChange(boardID, key)
+	}
+
+	app.finishForm(nil, func() {
+		app.setStatusMessage("Updated %s", key)
+	})
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+const epicsView = "epics"
+
+// noEpic names the lane and the progress line of issues without an epic.
+const noEpic = "No epic"
+
+// epicGroup is the issues of a board that belong to one epic, shown as a
+// swimlane and as a line of the epic progress view.
+type epicGroup struct {
+	epic   *jira.Epic // nil for the issues without an epic
+	issues []jira.Issue
+	done   int
+	height int // Most cards of the group in one column
+}
+
+func (group epicGroup) name() string {
+	if group.epic == nil {
+		return noEpic
+	}
+	return epicName(group.epic)
+}
+
+// epicName is the name of an epic, or its key while the name is unknown.
+func epicName(epic *jira.Epic) string {
+	if epic.Name != "" {
+		return epic.Name
+	}
+	return epic.Key
+}
+
+// loadFields fetches the fields of the Jira instance once, to find custom
+// fields by type or name. It must not run on the gocui main loop.
+func (app *TUIApp) loadFields(ctx context.Context) {
+	app.mutex.Lock()
+	loaded := app.fields != nil
+	app.mutex.Unlock()
+	if loaded {
+		return
+	}
+
+	// Tried again on the next refresh
+	fields, err := app.jiraClient.GetFieldsContext(ctx)
+	if err != nil {
+		return
+	}
+	if fields == nil {
+		fields = []jira.Field{}
+	}
+	app.mutex.Lock()
+	app.fields = fields
+	app.mutex.Unlock()
+}
+
+// epicLinkField returns the ID of the legacy Epic Link field, configured or
+// found among the fields of the instance, "" when there is none. The caller
+// must hold app.mutex.
+func (app *TUIApp) epicLinkField() string {
+	if app.config.EpicLinkField != "" {
+		return app.config.EpicLinkField
+	}
+	return jira.EpicLinkField(app.fields)
+}
+
+// loadEpics names the epics of issues. Epics reached through the Epic Link
+// field are only known by key, those are looked up until the lookup
+// succeeds; epics it does not return, deleted or hidden from the user, are
+// not asked for again. It must not run on the gocui main loop.
+func (app *TUIApp) loadEpics(ctx context.Context, issues []jira.Issue) {
+	if app.config.EpicLinkField == "" {
+		app.loadFields(ctx)
+	}
+
+	app.mutex.Lock()
+	linkField := app.epicLinkField()
+	var missing []string
+	for _, issue := range issues {
+		epic := issue.Fields.EpicOf(linkField)
+		switch {
+		case epic == nil:
+		case epic.Name != "":
+			app.epics[epic.Key] = epic
+		case app.epics[epic.Key] == nil && !contains(missing, epic.Key):
+			missing = append(missing, epic.Key)
+		}
+	}
+	app.mutex.Unlock()
+	if len(missing) == 0 {
+		return
+	}
+
+	found, err := app.jiraClient.GetIssuesContext(ctx, missing)
+	if err != nil {
+		if ctx.Err() == nil {
+			app.setStatusMessage("Cannot load the names of epics: %v", err)
+		}
+		return
+	}
+	app.mutex.Lock()
+	// Epics Jira did not return keep their key as name
+	for _, key := range missing {
+		if app.epics[key] == nil {
+			app.epics[key] = &jira.Epic{Key: key}
+		}
+	}
+	for _, issue := range found {
+		app.epics[issue.Key] = &jira.Epic{
+			Key:     issue.Key,
+			Name:    issue.Fields.Summary,
+			Summary: issue.Fields.Summary,
+			Done:    issue.Fields.Status.Done(),
+		}
+	}
+	app.mutex.Unlock()
+}
+
+// issueEpic returns the epic of an issue, named when known, or nil for
+// issues without an epic. The caller must hold app.mutex.
+func (app *TUIApp) issueEpic(issue jira.Issue) *jira.Epic {
+	epic := issue.Fields.EpicOf(app.epicLinkField())
+	if epic != nil && epic.Name == "" {
+		if known := app.epics[epic.Key]; known != nil {
+			return known
+		}
+	}
+	return epic
+}
+
+// epicGroups splits the issues shown on a board by epic, sorted by epic
+// name with the issues without an epic last. Sub-tasks go with the epic of
+// their parent when it is among issues. Issues in hidden statuses are left
+// out. The caller must hold app.mutex.
+func (app *TUIApp) epicGroups(boardID string, issues []jira.Issue) []epicGroup {
+	byKey := make(map[string]jira.Issue, len(issues))
+	for _, issue := range issues {
+		byKey[issue.Key] = issue
+	}
+
+	groups := make(map[string]*epicGroup)
+	counts := make(map[string]map[string]int) // Cards per column, per group
+	for _, issue := range issues {
+		column := app.mapStatusToGroup(boardID, issue.Fields.Status)
+		if column == "" {
+			continue
+		}
+
+		epic := app.issueEpic(issue)
+		if parent, ok := byKey[parentKey(issue)]; epic == nil && ok {
+			epic = app.issueEpic(parent)
+		}
+		key := ""
+		if epic != nil {
+			key = epic.Key
+		}
+		group := groups[key]
+		if group == nil {
+			group = &epicGroup{epic: epic}
+			groups[key] = group
+			counts[key] = make(map[string]int)
+		}
+		group.issues = append(group.issues, issue)
+		if issue.Fields.Status.Done() {
+			group.done++
+		}
+		counts[key][column]++
+		if counts[key][column] > group.height {
+			group.height = counts[key][column]
+		}
+	}
+
+	result := make([]epicGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].epic == nil) != (result[j].epic == nil) {
+			return result[j].epic == nil
+		}
+		return strings.ToLower(result[i].name()) < strings.ToLower(result[j].name())
+	})
+	return result
+}
+
+// updateLaneView fills a status column split into swimlanes. Every lane is
+// as high as its fullest column so lanes line up across columns; lane
+// headers and padding are blank entries in viewIssues. The caller must hold
+// app.mutex.
+func (app *TUIApp) updateLaneView(v *gocui.View, boardID string, lanes []epicGroup, status string) {
+	v.Clear()
+
+	for _, lane := range lanes {
+		fmt.Fprintf(v, "\033[36m-- %s --\033[0m\n", lane.name())
+		app.viewIssues[v.Name()] = append(app.viewIssues[v.Name()], "")
+
+		shown := 0
+		for _, issue := range lane.issues {
+			if app.mapStatusToGroup(boardID, issue.Fields.Status) != status {
+				continue
+			}
+			fmt.Fprintln(v, app.cardLine(issue, status, false))
+			app.viewIssues[v.Name()] = append(app.viewIssues[v.Name()], issue.Key)
+			shown++
+		}
+		for ; shown < lane.height; shown++ {
+			fmt.Fprintln(v)
+			app.viewIssues[v.Name()] = append(app.viewIssues[v.Name()], "")
+		}
+	}
+}
+
+// toggleSwimlanes splits the columns into a lane per epic, or joins them
+// again.
+func (app *TUIApp) toggleSwimlanes(g *gocui.Gui, v *gocui.View) error {
+	app.mutex.Lock()
+	app.swimlanes = !app.swimlanes
+	lanes := app.swimlanes
+	app.mutex.Unlock()
+
+	if lanes {
+		app.setStatusMessage("Swimlanes by epic")
+	} else {
+		app.setStatusMessage("Swimlanes off")
+	}
+	return nil
+}
+
+// toggleEpicProgress swaps the activity panel for the epic progress of the
+// board, and back.
+func (app *TUIApp) toggleEpicProgress(g *gocui.Gui, v *gocui.View) error {
+	app.mutex.Lock()
+	app.epicProgress = !app.epicProgress
+	app.mutex.Unlock()
+	return nil
+}
+
+// updateEpicProgressView lists how many issues of each epic on the board
+// are done, as on the epic panels in Jira, and the total. Sub-tasks are
+// not counted. The caller must hold app.mutex.
+func (app *TUIApp) updateEpicProgressView(v *gocui.View, boardID string, issues []jira.Issue) {
+	v.Clear()
+
+	groups := app.epicGroups(boardID, withoutSubtasks(issues))
+	if len(groups) == 0 {
+		fmt.Fprintln(v, "No issues to display")
+		return
+	}
+
+	width, _ := v.Size()
+	total, done := 0, 0
+	for _, group := range groups {
+		fmt.Fprintln(v, progressLine(group.name(), group.done, len(group.issues), width))
+		total += len(group.issues)
+		done += group.done
+	}
+	fmt.Fprintln(v, strings.Repeat("-", width))
+	fmt.Fprintln(v, progressLine("Total", done, total, width))
+}
+
+// progressLine renders name, a bar and done/total fitted to width.
+func progressLine(name string, done, total, width int) string {
+	const barWidth = 10
+	filled := 0
+	if total > 0 {
+		filled = done * barWidth / total
+	}
+	counts := fmt.Sprintf(" [%s%s] %d/%d", strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled), done, total)
+
+	nameWidth := width - len(counts)
+	if nameWidth < 8 {
+		nameWidth = 8
+	}
+	if runes := []rune(name); len(runes) > nameWidth {
+		name = string(runes[:nameWidth])
+	}
+	return fmt.Sprintf("%-*s%s", nameWidth, name, counts)
+}
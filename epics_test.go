@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira"
+	"jira-boards-tui/pkg/jira/jiratest"
+)
+
+func TestLoadEpics(t *testing.T) {
+	const linkField = "customfield_10014"
+	linked := func(key, epicKey string) jira.Issue {
+		issue := testIssue(key, "To Do")
+		issue.Fields.Extra = map[string]json.RawMessage{linkField: json.RawMessage(`"` + epicKey + `"`)}
+		return issue
+	}
+	epic := testIssue("DEV-100", "In Progress")
+	epic.Fields.Summary = "Reporting"
+
+	cfg := config.Default()
+	cfg.EpicLinkField = linkField
+	app := newTestApp(t, cfg, jiratest.NewStore(jiratest.Fixtures{
+		Issues: map[int][]jira.Issue{0: {epic}},
+	}))
+	fake := app.jiraClient.(*jiratest.Fake)
+	issues := []jira.Issue{linked("DEV-1", "DEV-100"), linked("DEV-2", "DEV-100"), linked("DEV-3", "DEV-999")}
+	lookups := func() int {
+		count := 0
+		for _, call := range fake.Calls() {
+			if call == "GetIssuesContext" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// A failed lookup is tried again on the next refresh
+	fake.FailWith("GetIssuesContext", errors.New("connection reset by peer"))
+	app.loadEpics(context.Background(), issues)
+	if len(app.epics) != 0 {
+		t.Fatalf("failed lookup left epics %v", app.epics)
+	}
+
+	fake.FailWith("GetIssuesContext", nil)
+	app.loadEpics(context.Background(), issues)
+	if got := app.epics["DEV-100"]; got == nil || got.Name != "Reporting" {
+		t.Errorf("epic DEV-100 is %+v, want it named Reporting", got)
+	}
+	if got := app.epics["DEV-999"]; got == nil || got.Name != "" {
+		t.Errorf("missing epic DEV-999 is %+v, want it known by key only", got)
+	}
+	if got := lookups(); got != 2 {
+		t.Fatalf("looked up epics %d times, want 2", got)
+	}
+
+	// Epics Jira did not return are not asked for again
+	app.loadEpics(context.Background(), issues)
+	if got := lookups(); got != 2 {
+		t.Errorf("looked up epics %d times after all were known, want 2", got)
+	}
+}
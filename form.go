@@ -0,0 +1,372 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+const formView = "form"
+
+// formField is one editable line of a form.
+type formField struct {
+	ID       string
+	Label    string
+	Hint     string // Input format shown while editing, e.g. "YYYY-MM-DD"
+	Required bool
+	Options  []string // Value is picked from this list when set
+	Value    string
+	Error    string // Validation error shown next to the field
+	// Multiline fields are edited in the composer, their Value may span
+	// lines
+	Multiline bool
+	// Users searches the users of a user field, whose Value is then the
+	// display name of User
+	Users func(query string) ([]jira.User, error)
+	User  *jira.User
+}
+
+// formState describes a modal form. onSubmit receives the values keyed by
+// field ID; it is responsible for closing the form or reporting errors,
+// usually through finishForm once Jira has answered.
+type formState struct {
+	title      string
+	fields     []*formField
+	onSubmit   func(values map[string]string)
+	message    string // Error not tied to a single field
+	submitting bool
+	returnTo   string
+}
+
+// showForm opens a modal form. It must run on the gocui main loop.
+func (app *TUIApp) showForm(title string, fields []*formField, onSubmit func(values map[string]string)) {
+	app.gui.DeleteView(formView)
+	app.form = &formState{
+		title:    title,
+		fields:   fields,
+		onSubmit: onSubmit,
+		returnTo: app.currentViewName(formView),
+	}
+}
+
+// metaFormField builds a form field from Jira field metadata.
+func metaFormField(id string, meta jira.FieldMeta, value string) *formField {
+	field := &formField{
+		ID:       id,
+		Label:    meta.Name,
+		Required: meta.Required,
+		Options:  meta.Options(),
+		Value:    value,
+		// Long texts, the one-line prompt would flatten them
+		Multiline: id == "description" || id == "environment",
+	}
+	switch {
+	case meta.Schema.Type == "date":
+		field.Hint = "YYYY-MM-DD"
+	case meta.Schema.Type == "array" && len(field.Options) == 0:
+		field.Hint = "comma separated"
+	case meta.Schema.Type == "array":
+		// Multi-select fields are typed as labels, the picker takes one value
+		field.Hint = "comma separated: " + strings.Join(field.Options, ", ")
+		field.Options = nil
+	}
+	return field
+}
+
+// searchUsers lets the user fields among fields be filled by picking one of
+// the users search finds.
+func searchUsers(fields []*formField, meta map[string]jira.FieldMeta, search func(query string) ([]jira.User, error)) {
+	for _, field := range fields {
+		if meta[field.ID].IsUser() {
+			field.Users = search
+		}
+	}
+}
+
+// fieldValues converts form input into Jira field values keyed by field ID,
+// returning per-field errors for input the metadata rejects. Empty input is
+// left out, or sent as a cleared value when clear is set. Picked users are
+// sent the way the Jira instance identifies them.
+func (f *formState) fieldValues(meta map[string]jira.FieldMeta, values map[string]string, clear bool) (map[string]interface{}, map[string]string) {
+	payload := make(map[string]interface{})
+	errors := make(map[string]string)
+	for id, input := range values {
+		field := meta[id]
+		if user := f.pickedUser(id); user != nil && strings.TrimSpace(input) != "" {
+			payload[id] = field.UserValue(*user)
+			continue
+		}
+		if strings.TrimSpace(input) == "" {
+			switch {
+			case !clear:
+			case field.Schema.Type == "array":
+				payload[id] = []interface{}{}
+			default:
+				payload[id] = nil
+			}
+			continue
+		}
+
+		value, err := field.Value(input)
+		if err != nil {
+			errors[id] = err.Error()
+			continue
+		}
+		payload[id] = value
+	}
+	return payload, errors
+}
+
+// pickedUser returns the user picked for the field with the given ID, nil
+// when there is none.
+func (f *formState) pickedUser(id string) *jira.User {
+	for _, field := range f.fields {
+		if field.ID == id {
+			return field.User
+		}
+	}
+	return nil
+}
+
+func (app *TUIApp) layoutForm(g *gocui.Gui, maxX, maxY int) error {
+	if app.form == nil {
+		return nil
+	}
+
+	x0, y0, x1, y1 := centered(maxX, maxY, maxX*2/3, len(app.form.fields)+4)
+	v, err := g.SetView(formView, x0, y0, x1, y1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Highlight = true
+		v.SelBgColor = gocui.ColorBlue
+		v.SelFgColor = gocui.ColorWhite
+	}
+	v.Title = app.form.title + " (Enter: edit, Ctrl+S: submit, Esc: cancel)"
+	app.renderForm(v)
+
+	g.SetViewOnTop(formView)
+	g.SetCurrentView(formView)
+	return nil
+}
+
+func (app *TUIApp) renderForm(v *gocui.View) {
+	v.Clear()
+
+	width := 0
+	for _, field := range app.form.fields {
+		if w := len([]rune(field.Label)) + 1; w > width {
+			width = w
+		}
+	}
+
+	for _, field := range app.form.fields {
+		label := field.Label
+		if field.Required {
+			label += "*"
+		}
+		line := fmt.Sprintf("%-*s : %s", width, label, formValue(field))
+		if field.Error != "" {
+			line += "\033[31m  " + field.Error + "\033[0m"
+		}
+		fmt.Fprintln(v, line)
+	}
+
+	fmt.Fprintln(v, "")
+	switch {
+	case app.form.submitting:
+		fmt.Fprintln(v, "Submitting...")
+	case app.form.message != "":
+		fmt.Fprintln(v, "\033[31m"+app.form.message+"\033[0m")
+	}
+}
+
+// formValue is the value of a field as shown on its line of the form: the
+// first line of multi-line text, marked when there are more.
+func formValue(field *formField) string {
+	lines := strings.Split(strings.TrimSpace(field.Value), "\n")
+	if len(lines) > 1 {
+		return strings.TrimSpace(lines[0]) + " [...]"
+	}
+	return lines[0]
+}
+
+// selectedFormField returns the field under the cursor.
+func (app *TUIApp) selectedFormField(v *gocui.View) *formField {
+	if app.form == nil {
+		return nil
+	}
+	_, oy := v.Origin()
+	_, cy := v.Cursor()
+	if index := oy + cy; index >= 0 && index < len(app.form.fields) {
+		return app.form.fields[index]
+	}
+	return nil
+}
+
+func (app *TUIApp) formDown(g *gocui.Gui, v *gocui.View) error {
+	_, oy := v.Origin()
+	_, cy := v.Cursor()
+	if app.form == nil || oy+cy >= len(app.form.fields)-1 {
+		return nil
+	}
+	return app.cursorDown(g, v)
+}
+
+func (app *TUIApp) formUp(g *gocui.Gui, v *gocui.View) error {
+	return app.cursorUp(g, v)
+}
+
+// editFormField opens a picker, the composer or a prompt for the field under
+// the cursor.
+func (app *TUIApp) editFormField(g *gocui.Gui, v *gocui.View) error {
+	field := app.selectedFormField(v)
+	if field == nil || app.form.submitting {
+		return nil
+	}
+
+	if field.Users != nil {
+		var shortcuts []string
+		if !field.Required {
+			shortcuts = append(shortcuts, "> None")
+		}
+		app.showUserPicker(field.Label, shortcuts, field.Users, func(shortcut int, user *jira.User) {
+			field.Value, field.User, field.Error = "", user, ""
+			if user != nil {
+				field.Value = user.DisplayName
+			}
+		})
+		return nil
+	}
+
+	if len(field.Options) > 0 {
+		app.showPicker(field.Label, field.Options, func(index int) error {
+			field.Value = field.Options[index]
+			field.Error = ""
+			return nil
+		})
+		return nil
+	}
+
+	if field.Multiline {
+		app.showComposer(field.Label, field.Value, func(text string) {
+			field.Value = text
+			field.Error = ""
+			app.closeComposer(app.gui)
+		})
+		app.composer.allowEmpty = !field.Required
+		return nil
+	}
+
+	title := field.Label
+	if field.Hint != "" {
+		title += " (" + field.Hint + ")"
+	}
+	app.showPrompt(title, field.Value, false, func(value string) error {
+		field.Value = value
+		field.Error = ""
+		return nil
+	})
+	return nil
+}
+
+func (app *TUIApp) submitForm(g *gocui.Gui, v *gocui.View) error {
+	if app.form == nil || app.form.submitting {
+		return nil
+	}
+
+	values := make(map[string]string)
+	valid := true
+	for _, field := range app.form.fields {
+		field.Error = ""
+		if field.Required && strings.TrimSpace(field.Value) == "" {
+			field.Error = "required"
+			valid = false
+		}
+		values[field.ID] = field.Value
+	}
+	app.form.message = ""
+	if !valid {
+		return nil
+	}
+
+	app.form.onSubmit(values)
+	return nil
+}
+
+func (app *TUIApp) cancelForm(g *gocui.Gui, v *gocui.View) error {
+	if app.form != nil && app.form.submitting {
+		return nil
+	}
+	app.closeForm(g)
+	return nil
+}
+
+func (app *TUIApp) closeForm(g *gocui.Gui) {
+	returnTo := ""
+	if app.form != nil {
+		returnTo = app.form.returnTo
+	}
+	app.form = nil
+	g.DeleteView(formView)
+	restoreFocus(g, returnTo)
+}
+
+// setFormErrors shows per-field validation errors, e.g. from Jira's
+// "errors" object, and returns false if any were set.
+func (app *TUIApp) setFormErrors(errors map[string]string) bool {
+	if app.form == nil || len(errors) == 0 {
+		return true
+	}
+	unmatched := []string{}
+	for id, message := range errors {
+		matched := false
+		for _, field := range app.form.fields {
+			if field.ID == id {
+				field.Error = message
+				matched = true
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, id+": "+message)
+		}
+	}
+	if len(unmatched) > 0 {
+		app.form.message = strings.Join(unmatched, "; ")
+	}
+	return false
+}
+
+// finishForm reports the outcome of a submission made from a background
+// goroutine: on success the form closes and onSuccess runs, otherwise Jira's
+// field errors are shown inline and the form stays open.
+func (app *TUIApp) finishForm(err error, onSuccess func()) {
+	app.update(func(g *gocui.Gui) error {
+		if app.form == nil {
+			return nil
+		}
+		app.form.submitting = false
+
+		if err == nil {
+			app.closeForm(g)
+			if onSuccess != nil {
+				onSuccess()
+			}
+			return nil
+		}
+
+		if apiErr, ok := jira.AsAPIError(err); ok && len(apiErr.Errors) > 0 {
+			app.setFormErrors(apiErr.Errors)
+			if len(apiErr.ErrorMessages) > 0 {
+				app.form.message = strings.Join(apiErr.ErrorMessages, "; ")
+			}
+			return nil
+		}
+		app.form.message = err.Error()
+		return nil
+	})
+}
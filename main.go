@@ -3,19 +3,59 @@ package main
 import (
 	"flag"
 	"fmt"
+	"jira-boards-tui/pkg/jira"
 	"log"
 	"os"
 )
 
+// credentials collected from command line flags and environment variables
+type credentials struct {
+	authType string
+	username string
+	password string
+	token    string
+}
+
+// authenticator builds the jira.Authenticator for the credentials' type.
+func (c credentials) authenticator(baseURL string) (jira.Authenticator, error) {
+	switch c.authType {
+	case "basic", "session":
+		if c.username == "" || c.password == "" {
+			return nil, fmt.Errorf("%s authentication requires JIRA_USERNAME and JIRA_PASSWORD (or -username and -password)", c.authType)
+		}
+		if c.authType == "session" {
+			return jira.NewSessionAuth(baseURL, c.username, c.password), nil
+		}
+		return &jira.BasicAuth{Username: c.username, Password: c.password}, nil
+	case "bearer":
+		if c.token == "" {
+			return nil, fmt.Errorf("bearer authentication requires JIRA_TOKEN (or -token)")
+		}
+		return &jira.BearerAuth{Token: c.token}, nil
+	default:
+		return nil, fmt.Errorf("unknown authentication type %q (use basic, bearer or session)", c.authType)
+	}
+}
+
 func main() {
 	var (
 		configPath = flag.String("config", "config.json", "Path to configuration file")
 		username   = flag.String("username", "", "Jira username")
 		password   = flag.String("password", "", "Jira password")
+		token      = flag.String("token", "", "Jira Personal Access Token")
+		authType   = flag.String("auth", "", "Authentication type: basic, bearer or session (overrides config)")
 		tuiMode    = flag.Bool("tui", false, "Run in TUI mode")
+		demoMode   = flag.Bool("demo", false, "Run the TUI against a simulated Jira, no credentials needed")
 	)
 	flag.Parse()
 
+	if *demoMode {
+		if err := runDemo(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Get credentials from environment variables or command line flags
 	if *username == "" {
 		*username = os.Getenv("JIRA_USERNAME")
@@ -23,17 +63,27 @@ func main() {
 	if *password == "" {
 		*password = os.Getenv("JIRA_PASSWORD")
 	}
+	if *token == "" {
+		*token = os.Getenv("JIRA_TOKEN")
+	}
 
 	// Validate required credentials
-	if *username == "" || *password == "" {
+	if *token == "" && (*username == "" || *password == "") {
 		fmt.Println("Error: JIRA credentials are required")
 		fmt.Println("Set JIRA_USERNAME and JIRA_PASSWORD environment variables")
 		fmt.Println("or use -username and -password flags")
+		fmt.Println("For Personal Access Tokens set JIRA_TOKEN or use -token")
 		os.Exit(1)
 	}
 
 	if *tuiMode {
-		app, err := NewTUIApp(*configPath, *username, *password)
+		creds := credentials{
+			authType: *authType,
+			username: *username,
+			password: *password,
+			token:    *token,
+		}
+		app, err := NewTUIApp(*configPath, creds)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,71 @@
+package main
+
+import "github.com/jroimartin/gocui"
+
+// layoutOverlays draws the modal views on top of the board, the dependency
+// view first and prompt last, so the most recently stacked overlay gets the
+// focus.
+func (app *TUIApp) layoutOverlays(g *gocui.Gui, maxX, maxY int, keep map[string]bool) error {
+	if err := app.layoutDependencies(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := app.layoutDetail(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := app.layoutForm(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := app.layoutComposer(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := app.layoutPicker(g, maxX, maxY); err != nil {
+		return err
+	}
+	if err := app.layoutPrompt(g, maxX, maxY); err != nil {
+		return err
+	}
+
+	keep[dependenciesView] = app.dependencies != nil
+	keep[detailView] = app.detail != nil
+	keep[formView] = app.form != nil
+	keep[composerView] = app.composer != nil
+	keep[pickerView] = app.picker != nil
+	keep[promptView] = app.prompt != nil
+	return nil
+}
+
+// modalOpen reports whether an overlay currently owns the keyboard.
+func (app *TUIApp) modalOpen() bool {
+	return app.prompt != nil || app.picker != nil || app.form != nil || app.composer != nil || app.detail != nil || app.dependencies != nil
+}
+
+// currentViewName returns the focused view, used as the return target of
+// a new overlay.
+func (app *TUIApp) currentViewName(exclude string) string {
+	if v := app.gui.CurrentView(); v != nil && v.Name() != exclude {
+		return v.Name()
+	}
+	return ""
+}
+
+// restoreFocus returns focus to the view an overlay was opened from. Focus
+// must never stay on a deleted overlay or keys would go to a dead view.
+func restoreFocus(g *gocui.Gui, returnTo string) {
+	if _, err := g.SetCurrentView(returnTo); err != nil {
+		g.SetCurrentView("header")
+	}
+}
+
+// centered returns the corners of a box of the given size in the middle of
+// the screen, shrunk to fit.
+func centered(maxX, maxY, width, height int) (x0, y0, x1, y1 int) {
+	if width > maxX-2 {
+		width = maxX - 2
+	}
+	if height > maxY-2 {
+		height = maxY - 2
+	}
+	x0 = (maxX - width) / 2
+	y0 = (maxY - height) / 2
+	return x0, y0, x0 + width, y0 + height
+}
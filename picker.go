@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+)
+
+const pickerView = "picker"
+
+// pickerState describes a modal list to choose one entry from. With
+// onQuery set the list is searchable: typed text goes to query and onQuery
+// is expected to replace the items through setPickerItems.
+type pickerState struct {
+	title    string
+	items    []string
+	onSelect func(index int) error
+	query    string
+	onQuery  func(query string)
+	returnTo string
+}
+
+// showPicker opens a modal list. It must run on the gocui main loop.
+func (app *TUIApp) showPicker(title string, items []string, onSelect func(index int) error) {
+	app.gui.DeleteView(pickerView)
+	app.picker = &pickerState{
+		title:    title,
+		items:    items,
+		onSelect: onSelect,
+		returnTo: app.currentViewName(pickerView),
+	}
+}
+
+// showSearchPicker opens a picker whose items are looked up as the user
+// types. onQuery runs on the main loop for every change of the query,
+// including once for the empty query when the picker opens.
+func (app *TUIApp) showSearchPicker(title string, onQuery func(query string), onSelect func(index int) error) {
+	app.showPicker(title, nil, onSelect)
+	app.picker.onQuery = onQuery
+	onQuery("")
+}
+
+// setPickerItems replaces the entries of the open picker. It must run on
+// the gocui main loop.
+func (app *TUIApp) setPickerItems(items []string) {
+	if app.picker == nil {
+		return
+	}
+	app.picker.items = items
+	if v, err := app.gui.View(pickerView); err == nil {
+		v.SetCursor(0, 0)
+		v.SetOrigin(0, 0)
+	}
+}
+
+func (app *TUIApp) layoutPicker(g *gocui.Gui, maxX, maxY int) error {
+	if app.picker == nil {
+		return nil
+	}
+
+	searching := app.picker.onQuery != nil
+	title := app.picker.title + " (Enter: select, Esc: cancel)"
+	width := len([]rune(title)) + 2
+	height := len(app.picker.items) + 1
+	if searching {
+		// Keep the box still while results come and go
+		title = fmt.Sprintf("%s: %s_", app.picker.title, app.picker.query)
+		width, height = 60, 12
+	}
+	for _, item := range app.picker.items {
+		if w := len([]rune(item)) + 4; w > width {
+			width = w
+		}
+	}
+	x0, y0, x1, y1 := centered(maxX, maxY, width, height)
+
+	v, err := g.SetView(pickerView, x0, y0, x1, y1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Highlight = true
+		v.SelBgColor = gocui.ColorBlue
+		v.SelFgColor = gocui.ColorWhite
+		if searching {
+			v.Editable = true
+			v.Editor = gocui.EditorFunc(app.editPickerQuery)
+		}
+	}
+	v.Title = title
+	v.Clear()
+	for _, item := range app.picker.items {
+		fmt.Fprintln(v, item)
+	}
+
+	g.SetViewOnTop(pickerView)
+	g.SetCurrentView(pickerView)
+	return nil
+}
+
+func (app *TUIApp) pickerIndex(v *gocui.View) int {
+	_, oy := v.Origin()
+	_, cy := v.Cursor()
+	return oy + cy
+}
+
+// editPickerQuery is the editor of searchable pickers: it edits the query
+// instead of the list.
+func (app *TUIApp) editPickerQuery(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	if app.picker == nil || app.picker.onQuery == nil {
+		return
+	}
+
+	query := []rune(app.picker.query)
+	switch {
+	case ch != 0 && mod == 0:
+		query = append(query, ch)
+	case key == gocui.KeySpace:
+		query = append(query, ' ')
+	case (key == gocui.KeyBackspace || key == gocui.KeyBackspace2) && len(query) > 0:
+		query = query[:len(query)-1]
+	default:
+		return
+	}
+
+	app.picker.query = string(query)
+	app.picker.onQuery(app.picker.query)
+}
+
+// pickerKey returns a handler for a letter that navigates plain pickers but
+// must stay typeable in searchable ones.
+func (app *TUIApp) pickerKey(ch rune, navigate func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if app.picker != nil && app.picker.onQuery != nil {
+			app.editPickerQuery(v, 0, ch, 0)
+			return nil
+		}
+		return navigate(g, v)
+	}
+}
+
+func (app *TUIApp) pickerDown(g *gocui.Gui, v *gocui.View) error {
+	if app.picker == nil || app.pickerIndex(v) >= len(app.picker.items)-1 {
+		return nil
+	}
+	return app.cursorDown(g, v)
+}
+
+func (app *TUIApp) pickerUp(g *gocui.Gui, v *gocui.View) error {
+	return app.cursorUp(g, v)
+}
+
+func (app *TUIApp) closePicker(g *gocui.Gui) {
+	returnTo := ""
+	if app.picker != nil {
+		returnTo = app.picker.returnTo
+	}
+	app.picker = nil
+	g.DeleteView(pickerView)
+	restoreFocus(g, returnTo)
+}
+
+func (app *TUIApp) selectPicker(g *gocui.Gui, v *gocui.View) error {
+	if app.picker == nil {
+		return nil
+	}
+	index := app.pickerIndex(v)
+	if index < 0 || index >= len(app.picker.items) {
+		return nil
+	}
+	onSelect := app.picker.onSelect
+
+	// Close first so the callback can open a follow-up overlay
+	app.closePicker(g)
+	return onSelect(index)
+}
+
+func (app *TUIApp) cancelPicker(g *gocui.Gui, v *gocui.View) error {
+	app.closePicker(g)
+	return nil
+}
+
+// confirm asks a yes/no question and calls onYes if the user agrees. No is
+// preselected so a stray Enter does nothing harmful.
+func (app *TUIApp) confirm(title string, onYes func() error) {
+	app.showPicker(title, []string{"No", "Yes"}, func(index int) error {
+		if index == 1 {
+			return onYes()
+		}
+		return nil
+	})
+}
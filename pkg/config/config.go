@@ -1,33 +1,129 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
 type Board struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// JQL is set for tabs showing a search instead of a board's sprints
+	JQL string `json:"-"`
+	// Workflow replaces the global workflow for this board when set
+	Workflow *Workflow `json:"workflow,omitempty"`
 }
 
+// Query is a saved JQL search, shown as a tab after the boards.
+type Query struct {
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+// queryPrefix starts the IDs of query tabs, telling them from board IDs.
+const queryPrefix = "jql:"
+
+// QueryBoard returns the tab of a JQL search. Its ID is derived from the
+// JQL so the tab keeps its change history when it is saved or renamed.
+func QueryBoard(name, jql string) Board {
+	return Board{ID: queryPrefix + jql, Name: name, Description: jql, JQL: jql}
+}
+
+// IsQueryID reports whether boardID is the ID of a query tab.
+func IsQueryID(boardID string) bool {
+	return strings.HasPrefix(boardID, queryPrefix)
+}
+
+// StatusMapping places statuses in a column. Statuses holds names or
+// patterns, see MatchStatus.
 type StatusMapping struct {
 	Column   string   `json:"column"`
 	Statuses []string `json:"statuses"`
+	// StatusIDs match statuses by ID, which survives renames in Jira
+	StatusIDs []string `json:"statusIds,omitempty"`
+}
+
+// ColumnLimit is a work-in-progress constraint of a column, 0 meaning no
+// limit. ExcludeSubtasks leaves sub-tasks out of the count.
+type ColumnLimit struct {
+	Min             int  `json:"min,omitempty"`
+	Max             int  `json:"max,omitempty"`
+	ExcludeSubtasks bool `json:"excludeSubtasks,omitempty"`
 }
 
 type Workflow struct {
 	Columns       []string        `json:"columns"`
 	StatusMapping []StatusMapping `json:"statusMapping"`
 	AutoDetect    bool            `json:"autoDetect"`
+	// FromBoard takes columns, status mappings and limits from the board
+	// configuration in Jira, using the settings above when it cannot be read
+	FromBoard bool `json:"fromBoard,omitempty"`
+	// Limits per column name
+	Limits map[string]ColumnLimit `json:"limits,omitempty"`
+	// HiddenStatuses are left off the board and out of the statistics,
+	// "Closed" when not configured unless FromBoard is set. Patterns are
+	// allowed, see MatchStatus.
+	HiddenStatuses []string `json:"hiddenStatuses,omitempty"`
+	// CategoryColumns places statuses no mapping matches by their Jira
+	// status category, keyed by category name ("To Do", "In Progress",
+	// "Done") or key ("new", "indeterminate", "done")
+	CategoryColumns map[string]string `json:"categoryColumns,omitempty"`
+}
+
+type Retry struct {
+	MaxAttempts      int `json:"maxAttempts"`
+	InitialBackoffMs int `json:"initialBackoffMs"`
+	MaxBackoffMs     int `json:"maxBackoffMs"`
+}
+
+type RateLimit struct {
+	// RequestsPerSecond of 0 disables client-side rate limiting
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+}
+
+type Auth struct {
+	// Type is "basic" (default), "bearer" for Personal Access Tokens or
+	// "session" for cookie-based login. Secrets never live in the config.
+	Type string `json:"type"`
 }
 
 type Config struct {
+	// Boards holds the configured boards followed by a tab per saved query
 	Boards          []Board  `json:"boards"`
+	Queries         []Query  `json:"queries,omitempty"`
 	RefreshInterval int      `json:"refreshInterval"`
 	JiraURL         string   `json:"jiraURL"`
+	Auth            Auth     `json:"auth"`
 	Workflow        Workflow `json:"workflow"`
+	// PageSize is the number of items requested per page from Jira list endpoints
+	PageSize int `json:"pageSize,omitempty"`
+	// MaxResults caps the issues fetched for a sprint, board, backlog or
+	// search, 0 means unlimited
+	MaxResults int `json:"maxResults,omitempty"`
+	// Retry configures retries of rate limited and transient failures
+	Retry Retry `json:"retry"`
+	// RateLimit throttles requests to Jira, defaults apply when omitted
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// EpicLinkField is the ID of the legacy Epic Link custom field, looked
+	// up by its type in Jira when empty
+	EpicLinkField string `json:"epicLinkField,omitempty"`
+	// Swimlanes set to "epic" starts with the columns split into a lane per
+	// epic, "none" or empty without lanes
+	Swimlanes string `json:"swimlanes,omitempty"`
+	// HideSubtasks starts with sub-tasks rolled up into the card of their
+	// parent
+	HideSubtasks bool `json:"hideSubtasks,omitempty"`
+	// CustomFields lists the custom fields shown by their short names
+	CustomFields []CustomField `json:"customFields,omitempty"`
+	// CardSort orders the cards of each column by the custom field of that
+	// name, descending with a leading "-"; board order when empty
+	CardSort string `json:"cardSort,omitempty"`
 }
 
 func LoadConfig(filename string) (*Config, error) {
@@ -47,30 +143,184 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, err
 	}
 
-	// Set default workflow if not configured
-	if len(config.Workflow.Columns) == 0 {
-		config.setDefaultWorkflow()
+	config.applyDefaults()
+
+	if err := config.Workflow.validate(); err != nil {
+		return nil, fmt.Errorf("workflow: %w", err)
+	}
+	if config.Swimlanes != "" && config.Swimlanes != "none" && config.Swimlanes != "epic" {
+		return nil, fmt.Errorf("swimlanes: %q is not one of \"epic\" or \"none\"", config.Swimlanes)
+	}
+	if err := config.validateCustomFields(); err != nil {
+		return nil, err
+	}
+	for _, board := range config.Boards {
+		if board.Workflow == nil {
+			continue
+		}
+		if err := board.Workflow.validate(); err != nil {
+			return nil, fmt.Errorf("workflow of board %s: %w", board.ID, err)
+		}
 	}
 
 	return &config, nil
 }
 
-func (c *Config) setDefaultWorkflow() {
-	c.Workflow = Workflow{
-		Columns: []string{
-			"Open", "Blocked", "In Progress", "Code Review", 
-			"Ready for Test", "In Testing", "Tested", "Done",
-		},
-		StatusMapping: []StatusMapping{
-			{Column: "Open", Statuses: []string{"Open", "To Do", "Backlog", "Reopen", "Reopened"}},
-			{Column: "Blocked", Statuses: []string{"Blocked"}},
-			{Column: "In Progress", Statuses: []string{"In Progress", "In Development"}},
-			{Column: "Code Review", Statuses: []string{"Code Review", "Review", "Pull Request"}},
-			{Column: "Ready for Test", Statuses: []string{"Ready for Test", "Ready for Testing", "QA Ready"}},
-			{Column: "In Testing", Statuses: []string{"In Testing", "Testing", "QA"}},
-			{Column: "Tested", Statuses: []string{"Tested", "QA Done", "QA Complete"}},
-			{Column: "Done", Statuses: []string{"Done", "Resolved", "Complete"}},
-		},
-		AutoDetect: false,
-	}
-}
\ No newline at end of file
+// Default returns a configuration without boards, with every other setting
+// at its default value.
+func Default() *Config {
+	config := &Config{RefreshInterval: 60}
+	config.applyDefaults()
+	return config
+}
+
+func (c *Config) applyDefaults() {
+	c.Workflow.applyDefaults()
+	for _, board := range c.Boards {
+		if board.Workflow != nil {
+			board.Workflow.applyDefaults()
+		}
+	}
+
+	if c.RateLimit == nil {
+		c.RateLimit = &RateLimit{RequestsPerSecond: 5, Burst: 10}
+	}
+
+	for _, query := range c.Queries {
+		c.Boards = append(c.Boards, QueryBoard(query.Name, query.JQL))
+	}
+}
+
+// BoardWorkflow returns the workflow a board is drawn with: its own when
+// configured, the global one otherwise.
+func (c *Config) BoardWorkflow(boardID string) Workflow {
+	for _, board := range c.Boards {
+		if board.ID == boardID && board.Workflow != nil {
+			return *board.Workflow
+		}
+	}
+	return c.Workflow
+}
+
+func (w *Workflow) applyDefaults() {
+	// Set default columns if not configured
+	if len(w.Columns) == 0 {
+		w.setDefaultColumns()
+	}
+
+	// An explicit empty list shows closed issues. Boards hide statuses by
+	// leaving them out of their columns, so they get no default.
+	if w.HiddenStatuses == nil && !w.FromBoard {
+		w.HiddenStatuses = []string{"Closed"}
+	}
+}
+
+// setDefaultColumns sets the default columns and status mapping, keeping
+// the other settings of the workflow.
+func (w *Workflow) setDefaultColumns() {
+	w.Columns = []string{
+		"Open", "Blocked", "In Progress", "Code Review",
+		"Ready for Test", "In Testing", "Tested", "Done",
+	}
+	w.StatusMapping = []StatusMapping{
+		{Column: "Open", Statuses: []string{"Open", "To Do", "Backlog", "Reopen", "Reopened"}},
+		{Column: "Blocked", Statuses: []string{"Blocked"}},
+		{Column: "In Progress", Statuses: []string{"In Progress", "In Development"}},
+		{Column: "Code Review", Statuses: []string{"Code Review", "Review", "Pull Request"}},
+		{Column: "Ready for Test", Statuses: []string{"Ready for Test", "Ready for Testing", "QA Ready"}},
+		{Column: "In Testing", Statuses: []string{"In Testing", "Testing", "QA"}},
+		{Column: "Tested", Statuses: []string{"Tested", "QA Done", "QA Complete"}},
+		{Column: "Done", Statuses: []string{"Done", "Resolved", "Complete"}},
+	}
+}
+
+// SaveQuery stores a query in the config file, replacing a saved query with
+// the same JQL. Only the "queries" entry is rewritten, the rest of the file
+// is kept as it is.
+func SaveQuery(filename string, query Query) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	start, end, closing, err := findField(data, "queries")
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", filename, err)
+	}
+
+	var queries []Query
+	if start >= 0 {
+		if err := json.Unmarshal(data[start:end], &queries); err != nil {
+			return fmt.Errorf("parsing queries in %s: %w", filename, err)
+		}
+	}
+	replaced := false
+	for i := range queries {
+		if queries[i].JQL == query.JQL {
+			queries[i] = query
+			replaced = true
+		}
+	}
+	if !replaced {
+		queries = append(queries, query)
+	}
+	value, err := json.MarshalIndent(queries, "  ", "  ")
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if start >= 0 {
+		out = append(out, data[:start]...)
+		out = append(out, value...)
+		out = append(out, data[end:]...)
+	} else {
+		// Append the field after the last one, before the closing brace
+		last := bytes.TrimRight(data[:closing], " \t\r\n")
+		out = append(out, last...)
+		if !bytes.HasSuffix(last, []byte("{")) {
+			out = append(out, ',')
+		}
+		out = append(out, "\n  \"queries\": "...)
+		out = append(out, value...)
+		out = append(out, '\n')
+		out = append(out, data[closing:]...)
+	}
+
+	return os.WriteFile(filename, out, 0644)
+}
+
+// findField locates the value of a top-level field of a JSON object as the
+// byte range start:end, -1 when the field is missing, along with the offset
+// of the object's closing brace.
+func findField(data []byte, name string) (start, end, closing int, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return 0, 0, 0, fmt.Errorf("expected a JSON object")
+	}
+
+	start, end = -1, -1
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		valueStart := int(decoder.InputOffset())
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return 0, 0, 0, err
+		}
+		if token == name {
+			// The offset after the key still has the colon and blanks ahead
+			start = valueStart + bytes.IndexByte(data[valueStart:], ':') + 1
+			for start < len(data) && strings.ContainsRune(" \t\r\n", rune(data[start])) {
+				start++
+			}
+			end = int(decoder.InputOffset())
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return 0, 0, 0, err
+	}
+	return start, end, int(decoder.InputOffset()) - 1, nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CustomField shows a Jira custom field, such as story points, team or
+// flagged, under a short name used on cards, in card filters and for
+// sorting.
+type CustomField struct {
+	Name string `json:"name"`
+	// ID of the field, such as "customfield_10002"; looked up by JiraName,
+	// or by Name, among the fields of the instance when empty
+	ID       string `json:"id,omitempty"`
+	JiraName string `json:"jiraName,omitempty"`
+	// Card shows the value on cards
+	Card bool `json:"card,omitempty"`
+	// Sum adds up the values of a numeric field per assignee in the
+	// summary
+	Sum bool `json:"sum,omitempty"`
+}
+
+// LookupName is the name the field is looked up by in Jira when it has no
+// ID.
+func (f CustomField) LookupName() string {
+	if f.JiraName != "" {
+		return f.JiraName
+	}
+	return f.Name
+}
+
+// CustomField returns the custom field with the given short name, ignoring
+// case.
+func (c *Config) CustomField(name string) (CustomField, bool) {
+	for _, field := range c.CustomFields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return CustomField{}, false
+}
+
+// NeedsFieldLookup reports whether a custom field has no ID configured, so
+// the fields of the instance need to be looked up.
+func (c *Config) NeedsFieldLookup() bool {
+	for _, field := range c.CustomFields {
+		if field.ID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCustomFields checks that the short names can be told apart and
+// used in card filters, and that cards are sorted by one of them.
+func (c *Config) validateCustomFields() error {
+	seen := make(map[string]bool)
+	for _, field := range c.CustomFields {
+		name := strings.ToLower(field.Name)
+		if name == "" {
+			return fmt.Errorf("custom field without a name")
+		}
+		if strings.ContainsAny(name, " \t=!<>~\"-") {
+			return fmt.Errorf("custom field %q: names cannot contain spaces, quotes, dashes or =!<>~", field.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("custom field %q is configured twice", field.Name)
+		}
+		seen[name] = true
+	}
+
+	if c.CardSort != "" {
+		if _, ok := c.CustomField(strings.TrimPrefix(c.CardSort, "-")); !ok {
+			return fmt.Errorf("cardSort: %q is not a custom field", c.CardSort)
+		}
+	}
+	return nil
+}
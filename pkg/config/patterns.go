@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// patterns caches the compiled status patterns, which are matched against
+// every issue on each redraw.
+var patterns sync.Map
+
+// MatchStatus reports whether a status name matches an entry of a status
+// mapping or of the hidden statuses: a regular expression between slashes
+// such as "/^QA/", a glob with * and ? such as "Ready for *", or else the
+// exact name.
+func MatchStatus(pattern, status string) bool {
+	if !isPattern(pattern) {
+		return pattern == status
+	}
+	re, err := compilePattern(pattern)
+	return err == nil && re.MatchString(status)
+}
+
+func isPattern(pattern string) bool {
+	return isRegexp(pattern) || strings.ContainsAny(pattern, "*?")
+}
+
+func isRegexp(pattern string) bool {
+	return len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	var expr string
+	if isRegexp(pattern) {
+		expr = pattern[1 : len(pattern)-1]
+	} else {
+		// A glob matches the whole name
+		expr = regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, ".*")
+		expr = strings.ReplaceAll(expr, `\?`, ".")
+		expr = "^" + expr + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// validate reports status patterns of the workflow that do not compile.
+func (w *Workflow) validate() error {
+	entries := append([]string(nil), w.HiddenStatuses...)
+	for _, mapping := range w.StatusMapping {
+		entries = append(entries, mapping.Statuses...)
+	}
+	for _, entry := range entries {
+		if !isPattern(entry) {
+			continue
+		}
+		if _, err := compilePattern(entry); err != nil {
+			return fmt.Errorf("invalid status pattern %q: %w", entry, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestMatchStatus(t *testing.T) {
+	tests := []struct {
+		pattern string
+		status  string
+		want    bool
+	}{
+		{"Done", "Done", true},
+		{"Done", "done", false},
+		{"Done", "Not Done", false},
+		{"Ready for *", "Ready for Test", true},
+		{"Ready for *", "Ready for ", true},
+		{"Ready for *", "Not Ready for Test", false},
+		{"QA?", "QA1", true},
+		{"QA?", "QA", false},
+		{"C++ (*)", "C++ (legacy)", true},
+		{"In.Test", "In Test", false},
+		{"/^(In )?Test/", "In Testing", true},
+		{"/^(In )?Test/", "Testing", true},
+		{"/^(In )?Test/", "Retest", false},
+		{"/(?i)^done$/", "DONE", true},
+		{"/[/", "[", false},
+		{"/", "/", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchStatus(tt.pattern, tt.status); got != tt.want {
+			t.Errorf("MatchStatus(%q, %q) = %v, want %v", tt.pattern, tt.status, got, tt.want)
+		}
+	}
+}
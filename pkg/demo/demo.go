@@ -0,0 +1,745 @@
+// Package demo simulates a Jira instance for onboarding and for reproducing
+// UI bugs without credentials. It generates boards with sprints, assignees,
+// priorities, changelogs and comments into a jiratest.Store and keeps
+// mutating issues so change detection can be watched live.
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira"
+	"jira-boards-tui/pkg/jira/jiratest"
+)
+
+// jiraTime is the timestamp layout Jira uses and the TUI parses.
+const jiraTime = "2006-01-02T15:04:05.000-0700"
+
+// storyPointsField is the custom field scrum boards estimate issues with.
+const storyPointsField = "customfield_10002"
+
+// epicLinkField is the legacy Epic Link field, holding the key of the epic.
+const epicLinkField = "customfield_10014"
+
+// teamField holds the team owning an issue, flaggedField the "Impediment"
+// flag of issues that cannot move on.
+const (
+	teamField    = "customfield_10001"
+	flaggedField = "customfield_10021"
+)
+
+// epicType is the issue type of epics, one level above stories.
+var epicType = jira.IssueType{Name: "Epic", HierarchyLevel: 1}
+
+// blocksLink is the link type Jira ships for blocking dependencies.
+var blocksLink = jira.IssueLinkType{ID: "10000", Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+
+// column is a board column as configured in Jira, with statuses by name.
+type column struct {
+	name     string
+	statuses []string
+	min, max int
+}
+
+type board struct {
+	id          string
+	name        string
+	description string
+	project     string
+	kanban      bool             // Issues come from the board filter instead of sprints
+	workflow    *config.Workflow // Configured for the board instead of taken from Jira
+	epicLink    bool             // Issues name their epic in the Epic Link field instead of as parent
+}
+
+var (
+	boards = []board{
+		{id: "1", name: "Development", description: "Simulated development board", project: "DEV"},
+		{id: "2", name: "Testing", description: "Simulated QA board", project: "QA", epicLink: true},
+		{id: "3", name: "Product", description: "Simulated product board", project: "PRD", workflow: &config.Workflow{
+			Columns: []string{"Planned", "Building", "Verifying", "Shipped"},
+			StatusMapping: []config.StatusMapping{
+				{Column: "Planned", Statuses: []string{"Open", "Reopened", "Blocked"}},
+				{Column: "Building", Statuses: []string{"In Progress", "Code Review"}},
+				{Column: "Verifying", Statuses: []string{"Ready for *", "/^(In )?Test/"}},
+				{Column: "Shipped", Statuses: []string{"Done"}},
+			},
+			HiddenStatuses: []string{"Closed"},
+		}},
+		{id: "4", name: "Operations", description: "Simulated Kanban board", project: "OPS", kanban: true, epicLink: true},
+	}
+
+	people = []jira.Assignee{
+		{Name: "alice", DisplayName: "Alice Martin"},
+		{Name: "bob", DisplayName: "Bob Novak"},
+		{Name: "carol", DisplayName: "Carol Diaz"},
+		{Name: "dmitry", DisplayName: "Dmitry Orlov"},
+		{Name: "erin", DisplayName: "Erin Walsh"},
+		{Name: "farid", DisplayName: "Farid Haddad"},
+	}
+
+	priorities  = []string{"Highest", "High", "Medium", "Medium", "Low", "Lowest"}
+	storyPoints = []int{1, 2, 3, 3, 5, 8, 13}
+	epicNames   = []string{"Onboarding", "Payments", "Reporting"}
+	teams       = []string{"Core", "Growth", "Platform"}
+	// subtaskSteps name the sub-tasks stories are split into, in order
+	subtaskSteps = []string{"Backend changes", "Frontend changes", "Write tests", "Update documentation", "Deploy to staging"}
+	issueTypes   = []string{"Story", "Story", "Bug", "Task"}
+
+	// flow is the order issues move through, matching the default workflow
+	flow = []string{"Open", "In Progress", "Code Review", "Ready for Test", "In Testing", "Tested", "Done"}
+
+	// kanbanColumns lay out the Kanban board with fewer columns than the
+	// default workflow, limiting work in progress
+	kanbanColumns = []column{
+		{name: "To Do", statuses: []string{"Open", "Reopened"}},
+		{name: "Doing", statuses: []string{"In Progress", "Blocked"}, max: 4},
+		{name: "Review", statuses: []string{"Code Review"}, max: 2},
+		{name: "Verify", statuses: []string{"Ready for Test", "In Testing", "Tested"}, min: 1, max: 5},
+		{name: "Done", statuses: []string{"Done"}},
+	}
+
+	verbs   = []string{"Add", "Fix", "Refactor", "Document", "Speed up", "Validate", "Migrate", "Remove"}
+	objects = []string{
+		"login form", "search results paging", "invoice export", "user avatar upload",
+		"password reset email", "audit log", "dashboard widgets", "API rate limiter",
+		"mobile navigation", "billing webhook", "report scheduler", "feature flags",
+	}
+	comments = []string{
+		"Looks good to me.", "Can we add a test for this?", "Blocked on the API change.",
+		"Deployed to staging.", "Reproduced locally, investigating.", "Updated the PR.",
+		"Please double check the edge cases.", "Done on my side.",
+	}
+)
+
+// Simulator owns the fake Jira data. Generation and mutation are
+// deterministic for a given seed.
+type Simulator struct {
+	store *jiratest.Store
+
+	mu         sync.Mutex
+	rng        *rand.Rand
+	nextKey    map[string]int         // Next issue number per project
+	statuses   map[string]jira.Status // By name; mutations run under the store lock
+	epics      map[string][]jira.Epic // Epics per project
+	commentSeq int
+}
+
+// NewSimulator generates a fresh set of boards, sprints and issues.
+func NewSimulator(seed int64) *Simulator {
+	s := &Simulator{
+		store:   jiratest.NewStore(jiratest.Fixtures{}),
+		rng:     rand.New(rand.NewSource(seed)),
+		nextKey: make(map[string]int),
+		epics:   make(map[string][]jira.Epic),
+	}
+
+	// Resolving an issue asks for a resolution, like most real workflows
+	s.store.SetWorkflow(append(append([]string(nil), flow...), "Blocked", "Reopened", "Closed", "On Hold"))
+	categories := map[string]string{"Open": "new", "Reopened": "new", "Done": "done", "Closed": "done"}
+	for _, status := range []string{"In Progress", "Code Review", "Ready for Test", "In Testing", "Tested", "Blocked", "On Hold"} {
+		categories[status] = "indeterminate"
+	}
+	s.store.SetStatusCategories(categories)
+	s.statuses = make(map[string]jira.Status)
+	for _, status := range s.store.Statuses() {
+		s.statuses[status.Name] = status
+	}
+	s.store.SetTransitionFields("Done", map[string]jira.FieldMeta{
+		"resolution": {
+			Name:     "Resolution",
+			Required: true,
+			Schema:   jira.FieldSchema{Type: "resolution", System: "resolution"},
+			AllowedValues: []jira.AllowedValue{
+				{ID: "1", Name: "Fixed"},
+				{ID: "2", Name: "Won't Fix"},
+				{ID: "3", Name: "Duplicate"},
+			},
+		},
+	})
+
+	s.store.SetFields(fields())
+
+	now := time.Now()
+	for i, b := range boards {
+		s.generateEpics(i, b, now)
+		boardType := "scrum"
+		if b.kanban {
+			boardType = "kanban"
+			s.generateKanbanBoard(b, now)
+		} else {
+			s.generateBoard(i, b, now)
+		}
+
+		id, _ := strconv.Atoi(b.id)
+		s.store.AddBoard(jira.Board{ID: id, Name: b.name, Type: boardType, Location: &jira.BoardLocation{ProjectKey: b.project, ProjectName: b.name}})
+		s.store.SetCreateMeta(b.project, createMeta())
+	}
+	s.generateLinks()
+
+	users := make([]jira.User, len(people))
+	for i, person := range people {
+		users[i] = jira.User{Name: person.Name, Key: person.Name, DisplayName: person.DisplayName, Active: true}
+	}
+	s.store.SetUsers(users)
+
+	return s
+}
+
+// Store returns the data served to the TUI, see jiratest.NewServer.
+func (s *Simulator) Store() *jiratest.Store {
+	return s.store
+}
+
+// Boards returns the simulated boards in config form.
+func (s *Simulator) Boards() []config.Board {
+	result := make([]config.Board, 0, len(boards))
+	for _, b := range boards {
+		result = append(result, config.Board{ID: b.id, Name: b.name, Description: b.description, Workflow: b.workflow})
+	}
+	return result
+}
+
+// Run mutates a random issue every interval until ctx is done.
+func (s *Simulator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Step(time.Now())
+		}
+	}
+}
+
+// Step applies one random change - a transition, reassignment, comment or
+// new issue - at the given time.
+func (s *Simulator) Step(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := boards[s.rng.Intn(len(boards))]
+	sprintID := 0 // Kanban issues live in the backlog
+	var issues []jira.Issue
+	if b.kanban {
+		issues, _ = s.store.BoardIssues(b.id, "")
+	} else {
+		// Sprints may be started and completed from the TUI meanwhile
+		active := s.store.Sprints(b.id, "active")
+		if len(active) == 0 {
+			return
+		}
+		sprintID = active[0].ID
+		issues = s.store.SprintIssues(sprintID)
+	}
+	roll := s.rng.Intn(100)
+
+	if len(issues) == 0 || roll >= 95 {
+		s.store.PutIssue(sprintID, s.newIssue(b, "Open", now.Add(-time.Minute), now))
+		return
+	}
+
+	key := issues[s.rng.Intn(len(issues))].Key
+	s.store.UpdateIssue(key, func(issue *jira.Issue) {
+		switch {
+		case roll < 60:
+			s.transition(issue, now)
+		case roll < 80:
+			s.reassign(issue, now)
+		default:
+			s.comment(issue, now)
+		}
+		issue.Fields.Updated = now.Format(jiraTime)
+	})
+}
+
+func (s *Simulator) generateBoard(index int, b board, now time.Time) {
+	baseID := (index + 1) * 100
+	sprintLength := 14 * 24 * time.Hour
+	activeStart := now.Add(-7 * 24 * time.Hour)
+
+	for n := 0; n < 4; n++ {
+		start := activeStart.Add(time.Duration(n-2) * sprintLength)
+		sprint := jira.Sprint{
+			ID:        baseID + n,
+			Name:      fmt.Sprintf("%s Sprint %d", b.project, 20+n),
+			StartDate: start.Format(jiraTime),
+			EndDate:   start.Add(sprintLength).Format(jiraTime),
+			Goal:      "Ship the " + objects[s.rng.Intn(len(objects))],
+		}
+
+		switch {
+		case n < 2:
+			sprint.State = "closed"
+			for i := 0; i < 6+s.rng.Intn(5); i++ {
+				status := "Done"
+				if s.rng.Intn(3) == 0 {
+					status = "Closed"
+				}
+				s.store.PutIssue(sprint.ID, s.newIssue(b, status, start, start.Add(sprintLength)))
+			}
+		case n == 2:
+			sprint.State = "active"
+			for i := 0; i < 14+s.rng.Intn(8); i++ {
+				status := flow[s.rng.Intn(len(flow))]
+				switch s.rng.Intn(12) {
+				case 0:
+					status = "Blocked"
+				case 1:
+					status = "On Hold"
+				}
+				story := s.newIssue(b, status, start, now)
+				s.store.PutIssue(sprint.ID, story)
+				s.addSubtasks(sprint.ID, b, story, start, now)
+			}
+		default:
+			sprint.State = "future"
+			for i := 0; i < 3+s.rng.Intn(3); i++ {
+				s.store.PutIssue(sprint.ID, s.newIssue(b, "Open", now.Add(-sprintLength), now))
+			}
+		}
+
+		s.store.AddSprint(b.id, sprint)
+	}
+
+	// The rest is planned in the backlog
+	for i := 0; i < 8+s.rng.Intn(6); i++ {
+		s.store.PutIssue(0, s.newIssue(b, "Open", now.Add(-2*sprintLength), now))
+	}
+
+	// Scrum boards have a column per column of the TUI's default workflow
+	var columns []column
+	for _, mapping := range config.Default().Workflow.StatusMapping {
+		columns = append(columns, column{name: mapping.Column, statuses: mapping.Statuses})
+	}
+	id, _ := strconv.Atoi(b.id)
+	s.store.SetBoardConfiguration(b.id, jira.BoardConfiguration{
+		ID:           id,
+		Name:         b.name,
+		Type:         "scrum",
+		Filter:       jira.BoardFilter{ID: b.id},
+		ColumnConfig: s.columnConfig(columns, "none"),
+		Estimation: &jira.BoardEstimation{
+			Type:  "field",
+			Field: &jira.EstimationField{FieldID: storyPointsField, DisplayName: "Story Points"},
+		},
+	}, "")
+}
+
+// generateEpics adds the epics of a board's project to the backlog. They
+// take the first issue keys of the project.
+func (s *Simulator) generateEpics(index int, b board, now time.Time) {
+	created := now.Add(-60 * 24 * time.Hour).Format(jiraTime)
+	for _, name := range epicNames {
+		s.nextKey[b.project]++
+		epic := jira.Epic{
+			ID:   (index+1)*1000 + s.nextKey[b.project],
+			Key:  fmt.Sprintf("%s-%d", b.project, s.nextKey[b.project]),
+			Name: name,
+		}
+		s.epics[b.project] = append(s.epics[b.project], epic)
+
+		issueType := epicType
+		s.store.PutIssue(0, jira.Issue{
+			Key: epic.Key,
+			Fields: jira.IssueFields{
+				Summary:     name,
+				Status:      s.statuses["In Progress"],
+				Description: "Generated by the demo simulator.",
+				Created:     created,
+				Updated:     created,
+				Priority:    &jira.Priority{Name: "Medium"},
+				IssueType:   &issueType,
+				Reporter:    &jira.Reporter{Name: people[0].Name, DisplayName: people[0].DisplayName},
+				Comment:     &jira.CommentBlock{},
+			},
+			Changelog: &jira.Changelog{},
+		})
+	}
+}
+
+// addSubtasks splits some stories into sub-tasks in the same sprint. Sub-tasks
+// are no further along the flow than their story, unless it is done.
+func (s *Simulator) addSubtasks(sprintID int, b board, story jira.Issue, from, to time.Time) {
+	if story.Fields.IssueType == nil || story.Fields.IssueType.Name != "Story" || s.rng.Intn(5) >= 2 {
+		return
+	}
+	reached := 0
+	for i, status := range flow {
+		if status == story.Fields.Status.Name {
+			reached = i
+		}
+	}
+
+	for _, step := range subtaskSteps[:2+s.rng.Intn(len(subtaskSteps)-1)] {
+		status := flow[s.rng.Intn(reached+1)]
+		if story.Fields.Status.Done() {
+			status = "Done"
+		}
+		subtask := s.newIssue(b, status, from, to)
+		subtask.Fields.Summary = step
+		subtask.Fields.IssueType = &jira.IssueType{Name: "Sub-task", Subtask: true, HierarchyLevel: -1}
+		// The store fills in the parent's fields, like Jira
+		subtask.Fields.Parent = &jira.IssueRef{Key: story.Key}
+		delete(subtask.Fields.Extra, storyPointsField)
+		delete(subtask.Fields.Extra, epicLinkField)
+		s.store.PutIssue(sprintID, subtask)
+	}
+}
+
+// generateLinks adds blocking dependencies between the issues on the boards:
+// every Blocked issue and a few others wait on another issue, now and then
+// one from another board. Blockers may be blocked in turn, forming chains.
+func (s *Simulator) generateLinks() {
+	var onBoards [][]jira.Issue
+	for _, b := range boards {
+		var issues []jira.Issue
+		if b.kanban {
+			issues, _ = s.store.BoardIssues(b.id, "")
+		} else {
+			for _, sprint := range s.store.Sprints(b.id, "active") {
+				issues = append(issues, s.store.SprintIssues(sprint.ID)...)
+			}
+		}
+		var cards []jira.Issue
+		for _, issue := range issues {
+			if !issue.Fields.IssueType.IsSubtask() && !issue.Fields.IssueType.IsEpic() {
+				cards = append(cards, issue)
+			}
+		}
+		onBoards = append(onBoards, cards)
+	}
+
+	linked := make(map[[2]string]bool)
+	for i, issues := range onBoards {
+		for _, issue := range issues {
+			if issue.Fields.Status.Name != "Blocked" && s.rng.Intn(4) != 0 {
+				continue
+			}
+			from := onBoards[i]
+			if s.rng.Intn(3) == 0 {
+				from = onBoards[s.rng.Intn(len(onBoards))]
+			}
+			if len(from) == 0 {
+				continue
+			}
+			blocker := from[s.rng.Intn(len(from))]
+			pair := [2]string{blocker.Key, issue.Key}
+			if blocker.Key == issue.Key || linked[pair] {
+				continue
+			}
+			linked[pair] = true
+			s.store.LinkIssues(blocksLink, blocker.Key, issue.Key)
+		}
+	}
+}
+
+// generateKanbanBoard fills a board without sprints. Its issues live in
+// the backlog and are selected by the board's filter; closed ones are left
+// out by the sub-filter, like Kanban boards that hide old work.
+func (s *Simulator) generateKanbanBoard(b board, now time.Time) {
+	from := now.Add(-21 * 24 * time.Hour)
+	for i := 0; i < 16+s.rng.Intn(8); i++ {
+		status := flow[s.rng.Intn(len(flow))]
+		if s.rng.Intn(5) == 0 {
+			status = "Closed"
+		}
+		s.store.PutIssue(0, s.newIssue(b, status, from, now))
+	}
+
+	id, _ := strconv.Atoi(b.id)
+	s.store.SetBoardConfiguration(b.id, jira.BoardConfiguration{
+		ID:           id,
+		Name:         b.name,
+		Type:         "kanban",
+		Filter:       jira.BoardFilter{ID: strconv.Itoa(10000 + id)},
+		SubQuery:     &jira.BoardFilter{Query: "status != Closed"},
+		ColumnConfig: s.columnConfig(kanbanColumns, "issueCount"),
+	}, fmt.Sprintf("project = %s AND issuetype != Epic", b.project))
+}
+
+// columnConfig resolves the statuses of columns to their IDs in the store.
+func (s *Simulator) columnConfig(columns []column, constraintType string) jira.ColumnConfig {
+	ids := make(map[string]string)
+	for _, status := range s.store.Statuses() {
+		ids[status.Name] = status.ID
+	}
+
+	result := jira.ColumnConfig{ConstraintType: constraintType}
+	for _, c := range columns {
+		boardColumn := jira.BoardColumn{Name: c.name, Min: c.min, Max: c.max}
+		for _, name := range c.statuses {
+			if id, ok := ids[name]; ok {
+				boardColumn.Statuses = append(boardColumn.Statuses, jira.BoardColumnStatus{ID: id})
+			}
+		}
+		result.Columns = append(result.Columns, boardColumn)
+	}
+	return result
+}
+
+// newIssue builds an issue that walked the flow up to status between from
+// and to, leaving a changelog entry per step and a few comments.
+func (s *Simulator) newIssue(b board, status string, from, to time.Time) jira.Issue {
+	// Issues created from the TUI take numbers too
+	key := ""
+	for key == "" {
+		s.nextKey[b.project]++
+		key = fmt.Sprintf("%s-%d", b.project, s.nextKey[b.project])
+		if _, taken := s.store.Issue(key); taken {
+			key = ""
+		}
+	}
+	created := from.Add(time.Duration(s.rng.Int63n(int64(to.Sub(from)/4) + 1)))
+
+	issue := jira.Issue{
+		Key: key,
+		Fields: jira.IssueFields{
+			Summary:     fmt.Sprintf("%s %s", verbs[s.rng.Intn(len(verbs))], objects[s.rng.Intn(len(objects))]),
+			Status:      s.statuses["Open"],
+			Description: "Generated by the demo simulator.",
+			Created:     created.Format(jiraTime),
+			Updated:     created.Format(jiraTime),
+			Priority:    &jira.Priority{Name: priorities[s.rng.Intn(len(priorities))]},
+			IssueType:   &jira.IssueType{Name: issueTypes[s.rng.Intn(len(issueTypes))]},
+			Reporter:    &jira.Reporter{Name: people[0].Name, DisplayName: people[0].DisplayName},
+			Comment:     &jira.CommentBlock{},
+		},
+		Changelog: &jira.Changelog{},
+	}
+	if s.rng.Intn(6) > 0 {
+		assignee := people[s.rng.Intn(len(people))]
+		issue.Fields.Assignee = &assignee
+	}
+	issue.Fields.Extra = make(map[string]json.RawMessage)
+	if s.rng.Intn(6) > 0 {
+		points := storyPoints[s.rng.Intn(len(storyPoints))]
+		issue.Fields.Extra[storyPointsField] = json.RawMessage(strconv.Itoa(points))
+	}
+	if s.rng.Intn(5) > 0 {
+		i := s.rng.Intn(len(teams))
+		team, _ := json.Marshal(map[string]string{"id": strconv.Itoa(100 + i), "name": teams[i]})
+		issue.Fields.Extra[teamField] = team
+	}
+	if s.rng.Intn(8) == 0 {
+		issue.Fields.Extra[flaggedField] = json.RawMessage(`[{"id":"10019","value":"Impediment"}]`)
+	}
+	if epics := s.epics[b.project]; len(epics) > 0 && s.rng.Intn(3) > 0 {
+		epic := epics[s.rng.Intn(len(epics))]
+		if b.epicLink {
+			link, _ := json.Marshal(epic.Key)
+			issue.Fields.Extra[epicLinkField] = link
+		} else {
+			issueType := epicType
+			issue.Fields.Parent = &jira.IssueRef{
+				ID:  strconv.Itoa(epic.ID),
+				Key: epic.Key,
+				Fields: jira.IssueRefFields{
+					Summary:   epic.Name,
+					Status:    s.statuses["In Progress"],
+					IssueType: &issueType,
+				},
+			}
+		}
+	}
+
+	// Spread the transitions between creation and to
+	steps := stepsTo(status)
+	at := created
+	for _, next := range steps {
+		at = at.Add(time.Duration(s.rng.Int63n(int64(to.Sub(at)/2) + 1)))
+		s.setStatus(&issue, next, at)
+	}
+	for i := s.rng.Intn(3); i > 0; i-- {
+		at = at.Add(time.Duration(s.rng.Int63n(int64(to.Sub(at)) + 1)))
+		s.comment(&issue, at)
+	}
+
+	return issue
+}
+
+// fields lists the fields of the simulated instance, custom fields
+// included, so the TUI can look them up by type.
+func fields() []jira.Field {
+	return []jira.Field{
+		{ID: "summary", Name: "Summary", Schema: jira.FieldSchema{Type: "string", System: "summary"}},
+		{ID: "status", Name: "Status", Schema: jira.FieldSchema{Type: "status", System: "status"}},
+		{ID: "priority", Name: "Priority", Schema: jira.FieldSchema{Type: "priority", System: "priority"}},
+		{ID: "assignee", Name: "Assignee", Schema: jira.FieldSchema{Type: "user", System: "assignee"}},
+		{ID: "parent", Name: "Parent"},
+		{ID: "labels", Name: "Labels", Schema: jira.FieldSchema{Type: "array", Items: "string", System: "labels"}},
+		{ID: storyPointsField, Name: "Story Points", Custom: true, Schema: jira.FieldSchema{
+			Type: "number", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:float", CustomID: 10002,
+		}},
+		{ID: teamField, Name: "Team", Custom: true, Schema: jira.FieldSchema{
+			Type: "team", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:atlassian-team", CustomID: 10001,
+		}},
+		{ID: flaggedField, Name: "Flagged", Custom: true, Schema: jira.FieldSchema{
+			Type: "array", Items: "option", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:multicheckboxes", CustomID: 10021,
+		}},
+		{ID: "customfield_10010", Name: "Severity", Custom: true, Schema: jira.FieldSchema{
+			Type: "option", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:select", CustomID: 10010,
+		}},
+		{ID: epicLinkField, Name: "Epic Link", Custom: true, Schema: jira.FieldSchema{
+			Type: "any", Custom: "com.pyxis.greenhopper.jira:gh-epic-link", CustomID: 10014,
+		}},
+	}
+}
+
+// createMeta describes the create screens of the simulated projects: bugs
+// additionally ask for a severity.
+func createMeta() []jira.IssueTypeMeta {
+	priorityValues := []jira.AllowedValue{}
+	for i, name := range []string{"Highest", "High", "Medium", "Low", "Lowest"} {
+		priorityValues = append(priorityValues, jira.AllowedValue{ID: strconv.Itoa(i + 1), Name: name})
+	}
+
+	var issueTypes []jira.IssueTypeMeta
+	for i, name := range []string{"Story", "Bug", "Task"} {
+		fields := map[string]jira.FieldMeta{
+			"project":     {Name: "Project", Required: true, Schema: jira.FieldSchema{Type: "project", System: "project"}},
+			"issuetype":   {Name: "Issue Type", Required: true, Schema: jira.FieldSchema{Type: "issuetype", System: "issuetype"}},
+			"summary":     {Name: "Summary", Required: true, Schema: jira.FieldSchema{Type: "string", System: "summary"}},
+			"description": {Name: "Description", Schema: jira.FieldSchema{Type: "string", System: "description"}},
+			"priority":    {Name: "Priority", Schema: jira.FieldSchema{Type: "priority", System: "priority"}, AllowedValues: priorityValues},
+			"assignee":    {Name: "Assignee", Schema: jira.FieldSchema{Type: "user", System: "assignee"}},
+			"duedate":     {Name: "Due Date", Schema: jira.FieldSchema{Type: "date", System: "duedate"}},
+			"labels":      {Name: "Labels", Schema: jira.FieldSchema{Type: "array", Items: "string", System: "labels"}},
+		}
+		if name == "Bug" {
+			fields["customfield_10010"] = jira.FieldMeta{
+				Name:     "Severity",
+				Required: true,
+				Schema:   jira.FieldSchema{Type: "option", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:select", CustomID: 10010},
+				AllowedValues: []jira.AllowedValue{
+					{ID: "10100", Value: "Critical"}, {ID: "10101", Value: "Major"}, {ID: "10102", Value: "Minor"},
+				},
+			}
+		}
+		issueTypes = append(issueTypes, jira.IssueTypeMeta{ID: strconv.Itoa(10001 + i), Name: name, Fields: fields})
+	}
+	return issueTypes
+}
+
+// stepsTo lists the statuses an issue passes through from Open to status.
+func stepsTo(status string) []string {
+	switch status {
+	case "Open":
+		return nil
+	case "Blocked":
+		return []string{"In Progress", "Blocked"}
+	case "Closed":
+		return append(stepsTo("Done"), "Closed")
+	}
+	for i, s := range flow {
+		if s == status {
+			return append([]string(nil), flow[1:i+1]...)
+		}
+	}
+	return []string{status}
+}
+
+// transition moves the issue one step along the flow; now and then it gets
+// blocked or bounces back from testing.
+func (s *Simulator) transition(issue *jira.Issue, now time.Time) {
+	current := issue.Fields.Status.Name
+	next := ""
+
+	switch {
+	case current == "Blocked":
+		next = "In Progress"
+	case current == "In Progress" && s.rng.Intn(8) == 0:
+		next = "Blocked"
+	case current == "In Progress" && s.rng.Intn(8) == 0:
+		// No column maps On Hold, its status category places it
+		next = "On Hold"
+	case current == "On Hold":
+		next = "In Progress"
+	case current == "In Testing" && s.rng.Intn(5) == 0:
+		next = "In Progress"
+	case current == "Done" || current == "Closed":
+		next = "Reopened"
+	case current == "Reopened":
+		next = "In Progress"
+	default:
+		for i, status := range flow {
+			if status == current && i+1 < len(flow) {
+				next = flow[i+1]
+			}
+		}
+	}
+	if next == "" {
+		next = "In Progress"
+	}
+
+	s.setStatus(issue, next, now)
+}
+
+func (s *Simulator) setStatus(issue *jira.Issue, status string, at time.Time) {
+	author := people[s.rng.Intn(len(people))]
+	if issue.Fields.Assignee != nil {
+		author = *issue.Fields.Assignee
+	}
+
+	issue.Changelog.Histories = append(issue.Changelog.Histories, jira.History{
+		Created: at.Format(jiraTime),
+		Author:  jira.Author{Name: author.Name, DisplayName: author.DisplayName},
+		Items: []jira.HistoryItem{{
+			Field:      "status",
+			FieldType:  "jira",
+			FromString: issue.Fields.Status.Name,
+			ToString:   status,
+		}},
+	})
+	issue.Fields.Status = s.statuses[status]
+	issue.Fields.Updated = at.Format(jiraTime)
+}
+
+func (s *Simulator) reassign(issue *jira.Issue, now time.Time) {
+	from := ""
+	if issue.Fields.Assignee != nil {
+		from = issue.Fields.Assignee.DisplayName
+	}
+	assignee := people[s.rng.Intn(len(people))]
+	lead := people[0]
+
+	issue.Changelog.Histories = append(issue.Changelog.Histories, jira.History{
+		Created: now.Format(jiraTime),
+		Author:  jira.Author{Name: lead.Name, DisplayName: lead.DisplayName},
+		Items: []jira.HistoryItem{{
+			Field:      "assignee",
+			FieldType:  "jira",
+			FromString: from,
+			To:         assignee.Name,
+			ToString:   assignee.DisplayName,
+		}},
+	})
+	issue.Fields.Assignee = &assignee
+}
+
+func (s *Simulator) comment(issue *jira.Issue, at time.Time) {
+	s.commentSeq++
+	author := people[s.rng.Intn(len(people))]
+	block := issue.Fields.Comment
+	if block == nil {
+		block = &jira.CommentBlock{}
+		issue.Fields.Comment = block
+	}
+
+	block.Comments = append(block.Comments, jira.Comment{
+		ID:      fmt.Sprintf("%d", 10000+s.commentSeq),
+		Body:    comments[s.rng.Intn(len(comments))],
+		Author:  jira.CommentUser{Name: author.Name, DisplayName: author.DisplayName},
+		Created: at.Format(jiraTime),
+		Updated: at.Format(jiraTime),
+	})
+	issue.Fields.Updated = at.Format(jiraTime)
+}
@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"context"
+	"time"
+)
+
+// API is the set of Jira operations the TUI depends on. *Client implements
+// it against a live server, package jiratest provides fakes for running the
+// board logic without network access.
+type API interface {
+	SetAuthenticator(auth Authenticator)
+
+	GetBoardContext(ctx context.Context, boardID string) (*Board, error)
+	GetBoardConfigurationContext(ctx context.Context, boardID string) (*BoardConfiguration, error)
+	GetBoardIssuesContext(ctx context.Context, boardID, jql string) ([]Issue, error)
+	GetBoardBacklogContext(ctx context.Context, boardID string) ([]Issue, error)
+	GetActiveSprintsContext(ctx context.Context, boardID string) ([]Sprint, error)
+	GetBoardSprintsContext(ctx context.Context, boardID string) ([]Sprint, error)
+	GetSprintDetailsContext(ctx context.Context, sprintID int) (*Sprint, error)
+	GetSprintIssuesViaJQLContext(ctx context.Context, sprintID int) ([]Issue, error)
+	GetIssueHistoryContext(ctx context.Context, issueKey string) (*Issue, error)
+	SearchIssueContext(ctx context.Context, issueKey string) (*Issue, error)
+	SearchContext(ctx context.Context, jql string) ([]Issue, error)
+	GetIssuesContext(ctx context.Context, keys []string) ([]Issue, error)
+	GetStatusesContext(ctx context.Context) ([]Status, error)
+	GetFieldsContext(ctx context.Context) ([]Field, error)
+
+	GetTransitionsContext(ctx context.Context, issueKey string) ([]Transition, error)
+	DoTransitionContext(ctx context.Context, issueKey, transitionID string, fields map[string]interface{}) error
+
+	MyselfContext(ctx context.Context) (*User, error)
+	FindAssignableUsersContext(ctx context.Context, issueKey, query string) ([]User, error)
+	FindProjectAssignableUsersContext(ctx context.Context, projectKey, query string) ([]User, error)
+	AssignIssueContext(ctx context.Context, issueKey string, user *User) error
+
+	GetCreateMetaContext(ctx context.Context, projectKey string) ([]IssueTypeMeta, error)
+	CreateIssueContext(ctx context.Context, fields map[string]interface{}) (*CreatedIssue, error)
+	MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error
+	MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error
+	CreateSprintContext(ctx context.Context, boardID string, sprint Sprint) (*Sprint, error)
+	StartSprintContext(ctx context.Context, sprintID int, start, end time.Time) (*Sprint, error)
+	CompleteSprintContext(ctx context.Context, sprintID, moveTo int) ([]string, error)
+	GetEditMetaContext(ctx context.Context, issueKey string) (map[string]FieldMeta, error)
+	UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error
+	AddCommentContext(ctx context.Context, issueKey, body string) (*Comment, error)
+	UpdateCommentContext(ctx context.Context, issueKey, commentID, body string) (*Comment, error)
+	DeleteCommentContext(ctx context.Context, issueKey, commentID string) error
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,145 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Authenticator adds credentials to every outgoing request.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// Reauthenticator is implemented by authenticators whose credentials can
+// expire. After a 401 the client calls Reauthenticate once and repeats the
+// request.
+type Reauthenticator interface {
+	Authenticator
+	Reauthenticate(ctx context.Context) error
+}
+
+// BasicAuth sends a username and password (or Cloud API token).
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a *BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// BearerAuth sends a Personal Access Token as "Authorization: Bearer",
+// required by Jira Data Center instances with basic auth disabled.
+type BearerAuth struct {
+	Token string
+}
+
+func (a *BearerAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// SessionAuth logs in through /rest/auth/1/session and sends the returned
+// session cookie. It logs in lazily on first use and again whenever the
+// session expires.
+type SessionAuth struct {
+	BaseURL    string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+
+	mu     sync.Mutex
+	cookie *http.Cookie
+}
+
+func NewSessionAuth(baseURL, username, password string) *SessionAuth {
+	return &SessionAuth{
+		BaseURL:    baseURL,
+		Username:   username,
+		Password:   password,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (a *SessionAuth) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cookie == nil {
+		if err := a.login(req.Context()); err != nil {
+			return err
+		}
+	}
+	req.AddCookie(a.cookie)
+	return nil
+}
+
+func (a *SessionAuth) Reauthenticate(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.cookie = nil
+	return a.login(ctx)
+}
+
+// login must be called with a.mu held.
+func (a *SessionAuth) login(ctx context.Context) error {
+	endpoint := "/rest/auth/1/session"
+	payload, err := json.Marshal(map[string]string{
+		"username": a.Username,
+		"password": a.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.BaseURL+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("logging in: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading login response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("logging in: %w", newAPIError(http.MethodPost, endpoint, resp, body))
+	}
+
+	var session struct {
+		Session struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"session"`
+	}
+	if err := json.Unmarshal(body, &session); err != nil {
+		return fmt.Errorf("parsing login response: %w", err)
+	}
+
+	if session.Session.Name != "" {
+		a.cookie = &http.Cookie{Name: session.Session.Name, Value: session.Session.Value}
+		return nil
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "JSESSIONID" {
+			a.cookie = &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("login response contained no session")
+}
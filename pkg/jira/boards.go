@@ -0,0 +1,157 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Board is an agile board. Location is the project the board belongs to,
+// absent for boards whose filter spans several projects.
+type Board struct {
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Type     string         `json:"type"` // "scrum" or "kanban"
+	Location *BoardLocation `json:"location,omitempty"`
+}
+
+type BoardLocation struct {
+	ProjectID   int    `json:"projectId,omitempty"`
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName,omitempty"`
+}
+
+func (c *Client) GetBoard(boardID string) (*Board, error) {
+	return c.GetBoardContext(context.Background(), boardID)
+}
+
+func (c *Client) GetBoardContext(ctx context.Context, boardID string) (*Board, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s", boardID)
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting board %s: %w", boardID, err)
+	}
+
+	var board Board
+	if err := json.Unmarshal(body, &board); err != nil {
+		return nil, fmt.Errorf("parsing board response: %w", err)
+	}
+
+	return &board, nil
+}
+
+// BoardConfiguration is the setup of a board: the saved filter selecting
+// its issues, on Kanban boards the sub-filter narrowing them further, the
+// columns with the statuses mapped to them and the field issues are
+// estimated with.
+type BoardConfiguration struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Type         string           `json:"type"`
+	Filter       BoardFilter      `json:"filter"`
+	SubQuery     *BoardFilter     `json:"subQuery,omitempty"`
+	ColumnConfig ColumnConfig     `json:"columnConfig"`
+	Estimation   *BoardEstimation `json:"estimation,omitempty"`
+}
+
+// BoardEstimation tells how a board estimates issues. Type is "field" or
+// "none"; the field is usually story points or "timeoriginalestimate", the
+// original time estimate in seconds.
+type BoardEstimation struct {
+	Type  string           `json:"type"`
+	Field *EstimationField `json:"field,omitempty"`
+}
+
+type EstimationField struct {
+	FieldID     string `json:"fieldId"`
+	DisplayName string `json:"displayName"`
+}
+
+// ColumnConfig lists the columns of a board in order. ConstraintType is
+// "none", "issueCount" or "issueCountExclSubs" and tells what the column
+// limits count.
+type ColumnConfig struct {
+	Columns        []BoardColumn `json:"columns"`
+	ConstraintType string        `json:"constraintType,omitempty"`
+}
+
+// BoardColumn is a column of a board. Statuses only carry IDs; Min and Max
+// are 0 when the column has no such limit.
+type BoardColumn struct {
+	Name     string              `json:"name"`
+	Statuses []BoardColumnStatus `json:"statuses"`
+	Min      int                 `json:"min,omitempty"`
+	Max      int                 `json:"max,omitempty"`
+}
+
+type BoardColumnStatus struct {
+	ID   string `json:"id"`
+	Self string `json:"self,omitempty"`
+}
+
+// BoardFilter refers to a saved filter by ID, or holds the JQL of a
+// sub-filter in Query.
+type BoardFilter struct {
+	ID    string `json:"id,omitempty"`
+	Self  string `json:"self,omitempty"`
+	Query string `json:"query,omitempty"`
+}
+
+func (c *Client) GetBoardConfiguration(boardID string) (*BoardConfiguration, error) {
+	return c.GetBoardConfigurationContext(context.Background(), boardID)
+}
+
+func (c *Client) GetBoardConfigurationContext(ctx context.Context, boardID string) (*BoardConfiguration, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/configuration", boardID)
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting configuration of board %s: %w", boardID, err)
+	}
+
+	var configuration BoardConfiguration
+	if err := json.Unmarshal(body, &configuration); err != nil {
+		return nil, fmt.Errorf("parsing board configuration response: %w", err)
+	}
+
+	return &configuration, nil
+}
+
+// GetBoardIssues returns the issues of a board's filter, independent of
+// sprints, narrowed by jql when it is not empty.
+func (c *Client) GetBoardIssues(boardID, jql string) ([]Issue, error) {
+	return c.GetBoardIssuesContext(context.Background(), boardID, jql)
+}
+
+func (c *Client) GetBoardIssuesContext(ctx context.Context, boardID, jql string) ([]Issue, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/issue?expand=changelog,comment&fields=*all", boardID)
+	if jql != "" {
+		endpoint += "&jql=" + url.QueryEscape(jql)
+	}
+
+	issues, err := paginate[Issue](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("getting issues of board %s: %w", boardID, err)
+	}
+
+	return issues, nil
+}
+
+// GetBoardBacklog returns the issues in the backlog of a board, those in no
+// active or future sprint, by rank as on the board in Jira.
+func (c *Client) GetBoardBacklog(boardID string) ([]Issue, error) {
+	return c.GetBoardBacklogContext(context.Background(), boardID)
+}
+
+func (c *Client) GetBoardBacklogContext(ctx context.Context, boardID string) ([]Issue, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/backlog?fields=*all&jql=%s", boardID, url.QueryEscape("ORDER BY Rank ASC"))
+
+	issues, err := paginate[Issue](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("getting backlog of board %s: %w", boardID, err)
+	}
+
+	return issues, nil
+}
@@ -2,11 +2,13 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -14,14 +16,24 @@ import (
 
 type Client struct {
 	httpClient *http.Client
-	username   string
-	password   string
+	authMu     sync.RWMutex
+	auth       Authenticator
 	baseURL    string
 	boardID    string
+	pageSize   int
+	maxResults int
+	retry      RetryPolicy
+	limiter    *rateLimiter
+
+	deploymentMu sync.Mutex
+	cloud        *bool // Deployment type once read, see isCloud
 }
 
 type Response struct {
-	Issues []Issue `json:"issues"`
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
 }
 
 type Issue struct {
@@ -42,6 +54,15 @@ type IssueFields struct {
 	IssueType   *IssueType    `json:"issuetype,omitempty"`
 	Reporter    *Reporter     `json:"reporter,omitempty"`
 	Comment     *CommentBlock `json:"comment,omitempty"`
+	Labels      []string      `json:"labels,omitempty"`
+	Epic        *Epic         `json:"epic,omitempty"`
+	Parent      *IssueRef     `json:"parent,omitempty"`
+	Subtasks    []IssueRef    `json:"subtasks,omitempty"`
+	IssueLinks  []IssueLink   `json:"issuelinks,omitempty"`
+
+	// Extra holds the fields not decoded above, such as custom fields,
+	// by field ID
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type Priority struct {
@@ -49,7 +70,11 @@ type Priority struct {
 }
 
 type IssueType struct {
-	Name string `json:"name"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask,omitempty"`
+	// HierarchyLevel is 1 for epics on Jira Cloud, 0 for standard issue
+	// types and -1 for sub-tasks; older servers leave it out
+	HierarchyLevel int `json:"hierarchyLevel,omitempty"`
 }
 
 type Reporter struct {
@@ -71,15 +96,29 @@ type Comment struct {
 
 type CommentUser struct {
 	Name        string `json:"name"`
+	Key         string `json:"key,omitempty"`
+	AccountID   string `json:"accountId,omitempty"`
 	DisplayName string `json:"displayName"`
 }
 
 type Status struct {
-	Name string `json:"name"`
+	ID             string          `json:"id,omitempty"`
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses across workflows. Key is "new" (To Do),
+// "indeterminate" (In Progress), "done" or "undefined".
+type StatusCategory struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	ColorName string `json:"colorName,omitempty"`
 }
 
 type Assignee struct {
 	Name        string `json:"name"`
+	AccountID   string `json:"accountId,omitempty"`
 	DisplayName string `json:"displayName"`
 }
 
@@ -108,7 +147,10 @@ type HistoryItem struct {
 }
 
 type SprintResponse struct {
-	Values []Sprint `json:"values"`
+	StartAt    int      `json:"startAt"`
+	MaxResults int      `json:"maxResults"`
+	IsLast     bool     `json:"isLast"`
+	Values     []Sprint `json:"values"`
 }
 
 type Sprint struct {
@@ -117,18 +159,39 @@ type Sprint struct {
 	State     string `json:"state"`
 	StartDate string `json:"startDate,omitempty"`
 	EndDate   string `json:"endDate,omitempty"`
+	Goal      string `json:"goal,omitempty"`
 }
 
 func NewClient(username, password, baseURL string) *Client {
+	return NewClientWithAuth(baseURL, &BasicAuth{Username: username, Password: password})
+}
+
+// NewClientWithAuth creates a client that authenticates every request with auth.
+func NewClientWithAuth(baseURL string, auth Authenticator) *Client {
 	return &Client{
 		httpClient: &http.Client{Timeout: 30 * time.Second},
-		username:   username,
-		password:   password,
+		auth:       auth,
 		baseURL:    baseURL,
 		boardID:    "", // Will be set via config
+		pageSize:   defaultPageSize,
+		retry:      DefaultRetryPolicy(),
+		limiter:    newRateLimiter(defaultRequestsPerSecond, defaultBurst),
 	}
 }
 
+// SetAuthenticator replaces the credentials used for subsequent requests.
+func (c *Client) SetAuthenticator(auth Authenticator) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.auth = auth
+}
+
+func (c *Client) authenticator() Authenticator {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.auth
+}
+
 func (c *Client) SetBoardID(boardID string) {
 	c.boardID = boardID
 }
@@ -137,39 +200,95 @@ func (c *Client) GetBoardID() string {
 	return c.boardID
 }
 
-func (c *Client) makeRequest(method, endpoint string, body []byte) ([]byte, error) {
+// makeRequest sends the request through the rate limiter and retries it
+// according to the client's RetryPolicy. Expired sessions are renewed once
+// per call without counting as an attempt.
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body []byte) ([]byte, error) {
+	reauthenticated := false
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, respBody, err := c.doRequest(ctx, method, endpoint, body)
+		if err == nil && resp.StatusCode < 400 {
+			return respBody, nil
+		}
+		if err == nil {
+			err = newAPIError(method, endpoint, resp, respBody)
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			if reauth, ok := c.authenticator().(Reauthenticator); ok {
+				reauthenticated = true
+				if loginErr := reauth.Reauthenticate(ctx); loginErr != nil {
+					return nil, loginErr
+				}
+				attempt--
+				continue
+			}
+		}
+
+		if attempt >= c.retry.MaxAttempts || !shouldRetry(method, resp, err) {
+			return nil, err
+		}
+
+		delay := c.retry.backoff(attempt)
+		if wait, ok := serverDelay(resp, time.Now()); ok {
+			// Longer waits are left to the caller, see APIError.RetryAfter,
+			// rather than blocking this request for up to an hour
+			if wait > c.retry.MaxBackoff {
+				return nil, err
+			}
+			// Hold back the other requests too, the quota is per user
+			delay = wait
+			c.limiter.pause(wait)
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequest performs a single HTTP round trip and reads the whole body.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body []byte) (*http.Response, []byte, error) {
 	url := c.baseURL + endpoint
 	
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(c.username, c.password)
+	if auth := c.authenticator(); auth != nil {
+		if err := auth.Authenticate(req); err != nil {
+			return nil, nil, fmt.Errorf("authenticating request: %w", err)
+		}
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("making request: %w", err)
+		return nil, nil, fmt.Errorf("making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, nil, fmt.Errorf("reading response: %w", err)
 	}
 
-	return respBody, nil
+	return resp, respBody, nil
 }
 
 func (c *Client) GetActiveSprintID() (int, error) {
+	return c.GetActiveSprintIDContext(context.Background())
+}
+
+func (c *Client) GetActiveSprintIDContext(ctx context.Context) (int, error) {
 	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint?state=active&maxResults=1", c.boardID)
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return 0, fmt.Errorf("getting active sprint: %w", err)
 	}
@@ -187,9 +306,13 @@ func (c *Client) GetActiveSprintID() (int, error) {
 }
 
 func (c *Client) GetSprintDetails(sprintID int) (*Sprint, error) {
+	return c.GetSprintDetailsContext(context.Background(), sprintID)
+}
+
+func (c *Client) GetSprintDetailsContext(ctx context.Context, sprintID int) (*Sprint, error) {
 	endpoint := fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprintID)
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("getting sprint details: %w", err)
 	}
@@ -203,69 +326,80 @@ func (c *Client) GetSprintDetails(sprintID int) (*Sprint, error) {
 }
 
 func (c *Client) GetAllActiveSprints() ([]Sprint, error) {
-	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint?state=active&maxResults=50", c.boardID)
-	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	return c.GetAllActiveSprintsContext(context.Background())
+}
+
+// GetAllActiveSprintsContext lists the active sprints of the board set with
+// SetBoardID. Code sharing the client between boards should use
+// GetActiveSprintsContext instead.
+func (c *Client) GetAllActiveSprintsContext(ctx context.Context) ([]Sprint, error) {
+	return c.GetActiveSprintsContext(ctx, c.boardID)
+}
+
+func (c *Client) GetActiveSprints(boardID string) ([]Sprint, error) {
+	return c.GetActiveSprintsContext(context.Background(), boardID)
+}
+
+// GetActiveSprintsContext lists the active sprints of a board.
+func (c *Client) GetActiveSprintsContext(ctx context.Context, boardID string) ([]Sprint, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint?state=active", boardID)
+
+	sprints, err := paginateAll[Sprint](ctx, c, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("getting active sprints: %w", err)
 	}
 
-	var sprintResponse SprintResponse
-	if err := json.Unmarshal(body, &sprintResponse); err != nil {
-		return nil, fmt.Errorf("parsing sprints response: %w", err)
-	}
-
-	return sprintResponse.Values, nil
+	return sprints, nil
 }
 
 func (c *Client) GetAllSprints() ([]Sprint, error) {
-	// Получаем активные спринты
-	activeSprints, err := c.GetAllActiveSprints()
-	if err != nil {
-		return nil, err
-	}
-	
-	// Получаем закрытые спринты
-	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint?state=closed&maxResults=100", c.boardID)
-	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	return c.GetAllSprintsContext(context.Background())
+}
+
+func (c *Client) GetAllSprintsContext(ctx context.Context) ([]Sprint, error) {
+	return c.GetBoardSprintsContext(ctx, c.boardID)
+}
+
+func (c *Client) GetBoardSprints(boardID string) ([]Sprint, error) {
+	return c.GetBoardSprintsContext(context.Background(), boardID)
+}
+
+// GetBoardSprintsContext lists the future, active and closed sprints of a
+// board, in the order Jira keeps them: closed ones first, oldest first.
+func (c *Client) GetBoardSprintsContext(ctx context.Context, boardID string) ([]Sprint, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint?state=future,active,closed", boardID)
+
+	sprints, err := paginateAll[Sprint](ctx, c, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("getting closed sprints: %w", err)
+		return nil, fmt.Errorf("getting sprints of board %s: %w", boardID, err)
 	}
 
-	var closedSprintResponse SprintResponse
-	if err := json.Unmarshal(body, &closedSprintResponse); err != nil {
-		return nil, fmt.Errorf("parsing closed sprints response: %w", err)
-	}
-	
-	// Объединяем все спринты
-	allSprints := make([]Sprint, 0, len(activeSprints)+len(closedSprintResponse.Values))
-	allSprints = append(allSprints, activeSprints...)
-	allSprints = append(allSprints, closedSprintResponse.Values...)
-	
-	return allSprints, nil
+	return sprints, nil
 }
 
 func (c *Client) GetSprintIssues(sprintID int) ([]Issue, error) {
+	return c.GetSprintIssuesContext(context.Background(), sprintID)
+}
+
+func (c *Client) GetSprintIssuesContext(ctx context.Context, sprintID int) ([]Issue, error) {
 	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%s/sprint/%s/issue?expand=changelog,comment&fields=*all&sort=status", c.boardID, strconv.Itoa(sprintID))
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	issues, err := paginate[Issue](ctx, c, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("getting sprint issues: %w", err)
 	}
 
-	var response Response
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("parsing issues response: %w", err)
-	}
-
-	return response.Issues, nil
+	return issues, nil
 }
 
 func (c *Client) GetIssueHistory(issueKey string) (*Issue, error) {
+	return c.GetIssueHistoryContext(context.Background(), issueKey)
+}
+
+func (c *Client) GetIssueHistoryContext(ctx context.Context, issueKey string) (*Issue, error) {
 	endpoint := fmt.Sprintf("/rest/api/latest/issue/%s?expand=changelog", issueKey)
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("getting issue history for %s: %w", issueKey, err)
 	}
@@ -278,26 +412,14 @@ func (c *Client) GetIssueHistory(issueKey string) (*Issue, error) {
 	return &issue, nil
 }
 
-func (c *Client) GetBacklogIssues() ([]Issue, error) {
-	endpoint := fmt.Sprintf("/rest/api/latest/search?jql=status=open")
-	
-	body, err := c.makeRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("getting backlog issues: %w", err)
-	}
-
-	var response Response
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("parsing backlog response: %w", err)
-	}
-
-	return response.Issues, nil
+func (c *Client) SearchIssue(issueKey string) (*Issue, error) {
+	return c.SearchIssueContext(context.Background(), issueKey)
 }
 
-func (c *Client) SearchIssue(issueKey string) (*Issue, error) {
+func (c *Client) SearchIssueContext(ctx context.Context, issueKey string) (*Issue, error) {
 	endpoint := fmt.Sprintf("/rest/api/latest/search?jql=key=%s&expand=changelog,comment&fields=*all", issueKey)
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("searching issue %s: %w", issueKey, err)
 	}
@@ -315,18 +437,17 @@ func (c *Client) SearchIssue(issueKey string) (*Issue, error) {
 }
 
 func (c *Client) GetSprintIssuesViaJQL(sprintID int) ([]Issue, error) {
+	return c.GetSprintIssuesViaJQLContext(context.Background(), sprintID)
+}
+
+func (c *Client) GetSprintIssuesViaJQLContext(ctx context.Context, sprintID int) ([]Issue, error) {
 	// Используем JQL поиск для получения всех задач спринта - более надежный метод, без фильтрации по проекту
-	endpoint := fmt.Sprintf("/rest/api/latest/search?jql=sprint=%d&expand=changelog,comment&fields=*all&maxResults=200", sprintID)
+	endpoint := fmt.Sprintf("/rest/api/latest/search?jql=sprint=%d&expand=changelog,comment&fields=*all", sprintID)
 	
-	body, err := c.makeRequest("GET", endpoint, nil)
+	issues, err := paginate[Issue](ctx, c, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("searching sprint issues via JQL: %w", err)
 	}
 
-	var response Response
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("parsing JQL search response: %w", err)
-	}
-
-	return response.Issues, nil
+	return issues, nil
 }
\ No newline at end of file
@@ -0,0 +1,69 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (c *Client) AddComment(issueKey, body string) (*Comment, error) {
+	return c.AddCommentContext(context.Background(), issueKey, body)
+}
+
+// AddCommentContext posts a comment and returns it as stored by Jira.
+func (c *Client) AddCommentContext(ctx context.Context, issueKey, body string) (*Comment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment", issueKey)
+
+	comment, err := c.sendComment(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("commenting on %s: %w", issueKey, err)
+	}
+	return comment, nil
+}
+
+func (c *Client) UpdateComment(issueKey, commentID, body string) (*Comment, error) {
+	return c.UpdateCommentContext(context.Background(), issueKey, commentID, body)
+}
+
+// UpdateCommentContext replaces the body of a comment. Jira only allows it
+// for the author's own comments unless the user is an administrator.
+func (c *Client) UpdateCommentContext(ctx context.Context, issueKey, commentID, body string) (*Comment, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", issueKey, commentID)
+
+	comment, err := c.sendComment(ctx, "PUT", endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("updating comment %s on %s: %w", commentID, issueKey, err)
+	}
+	return comment, nil
+}
+
+func (c *Client) DeleteComment(issueKey, commentID string) error {
+	return c.DeleteCommentContext(context.Background(), issueKey, commentID)
+}
+
+func (c *Client) DeleteCommentContext(ctx context.Context, issueKey, commentID string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment/%s", issueKey, commentID)
+
+	if _, err := c.makeRequest(ctx, "DELETE", endpoint, nil); err != nil {
+		return fmt.Errorf("deleting comment %s on %s: %w", commentID, issueKey, err)
+	}
+	return nil
+}
+
+func (c *Client) sendComment(ctx context.Context, method, endpoint, body string) (*Comment, error) {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return nil, fmt.Errorf("encoding comment: %w", err)
+	}
+
+	respBody, err := c.makeRequest(ctx, method, endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(respBody, &comment); err != nil {
+		return nil, fmt.Errorf("parsing comment response: %w", err)
+	}
+	return &comment, nil
+}
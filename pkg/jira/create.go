@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// IssueTypeMeta is an issue type of a project with the fields its create
+// screen offers, keyed by field ID.
+type IssueTypeMeta struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Subtask bool                 `json:"subtask"`
+	Fields  map[string]FieldMeta `json:"fields,omitempty"`
+}
+
+// CreatedIssue identifies an issue returned by CreateIssue.
+type CreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+type createMetaResponse struct {
+	Projects []struct {
+		Key        string          `json:"key"`
+		IssueTypes []IssueTypeMeta `json:"issuetypes"`
+	} `json:"projects"`
+}
+
+func (c *Client) GetCreateMeta(projectKey string) ([]IssueTypeMeta, error) {
+	return c.GetCreateMetaContext(context.Background(), projectKey)
+}
+
+// GetCreateMetaContext lists the issue types that can be created in the
+// project along with their fields. Jira 9 removed the combined endpoint in
+// favour of one request per issue type; both are supported.
+func (c *Client) GetCreateMetaContext(ctx context.Context, projectKey string) ([]IssueTypeMeta, error) {
+	endpoint := "/rest/api/2/issue/createmeta?projectKeys=" + url.QueryEscape(projectKey) + "&expand=projects.issuetypes.fields"
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if IsNotFound(err) {
+		return c.getCreateMetaPerType(ctx, projectKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting create metadata for %s: %w", projectKey, err)
+	}
+
+	var response createMetaResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("parsing create metadata: %w", err)
+	}
+
+	for _, project := range response.Projects {
+		if project.Key == projectKey {
+			return project.IssueTypes, nil
+		}
+	}
+	return nil, fmt.Errorf("no create metadata for project %s, it may not exist or you may not have permission to create issues", projectKey)
+}
+
+func (c *Client) getCreateMetaPerType(ctx context.Context, projectKey string) ([]IssueTypeMeta, error) {
+	base := "/rest/api/2/issue/createmeta/" + url.PathEscape(projectKey) + "/issuetypes"
+
+	issueTypes, err := paginateAll[IssueTypeMeta](ctx, c, base)
+	if err != nil {
+		return nil, fmt.Errorf("getting issue types of %s: %w", projectKey, err)
+	}
+
+	for i := range issueTypes {
+		fields, err := paginateAll[FieldMeta](ctx, c, base+"/"+url.PathEscape(issueTypes[i].ID))
+		if err != nil {
+			return nil, fmt.Errorf("getting fields of %s %s: %w", projectKey, issueTypes[i].Name, err)
+		}
+
+		issueTypes[i].Fields = make(map[string]FieldMeta, len(fields))
+		for _, field := range fields {
+			id := field.FieldID
+			if id == "" {
+				id = field.Key
+			}
+			issueTypes[i].Fields[id] = field
+		}
+	}
+
+	return issueTypes, nil
+}
+
+func (c *Client) CreateIssue(fields map[string]interface{}) (*CreatedIssue, error) {
+	return c.CreateIssueContext(context.Background(), fields)
+}
+
+// CreateIssueContext creates an issue from field values keyed by field ID,
+// in the format Value produces. project and issuetype are required.
+func (c *Client) CreateIssueContext(ctx context.Context, fields map[string]interface{}) (*CreatedIssue, error) {
+	body, err := json.Marshal(map[string]interface{}{"fields": c.encodeUsers(ctx, fields)})
+	if err != nil {
+		return nil, fmt.Errorf("encoding issue: %w", err)
+	}
+
+	respBody, err := c.makeRequest(ctx, "POST", "/rest/api/2/issue", body)
+	if err != nil {
+		return nil, fmt.Errorf("creating issue: %w", err)
+	}
+
+	var created CreatedIssue
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return nil, fmt.Errorf("parsing create response: %w", err)
+	}
+
+	return &created, nil
+}
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type editMetaResponse struct {
+	Fields map[string]FieldMeta `json:"fields"`
+}
+
+func (c *Client) GetEditMeta(issueKey string) (map[string]FieldMeta, error) {
+	return c.GetEditMetaContext(context.Background(), issueKey)
+}
+
+// GetEditMetaContext returns the fields of the issue the user may change,
+// keyed by field ID.
+func (c *Client) GetEditMetaContext(ctx context.Context, issueKey string) (map[string]FieldMeta, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/editmeta", issueKey)
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting edit metadata for %s: %w", issueKey, err)
+	}
+
+	var response editMetaResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("parsing edit metadata: %w", err)
+	}
+
+	return response.Fields, nil
+}
+
+func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) error {
+	return c.UpdateIssueContext(context.Background(), issueKey, fields)
+}
+
+// UpdateIssueContext sets field values keyed by field ID, in the format
+// FieldMeta.Value produces; a nil value clears the field. Validation
+// failures come back as an *APIError with per-field Errors.
+func (c *Client) UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueKey)
+
+	body, err := json.Marshal(map[string]interface{}{"fields": c.encodeUsers(ctx, fields)})
+	if err != nil {
+		return fmt.Errorf("encoding fields: %w", err)
+	}
+
+	if _, err := c.makeRequest(ctx, "PUT", endpoint, body); err != nil {
+		return fmt.Errorf("updating %s: %w", issueKey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// APIError is returned for Jira responses with status >= 400. Jira reports
+// failures as {"errorMessages": [...], "errors": {"field": "message"}}; when
+// the body is not in that format it is kept verbatim in Body.
+type APIError struct {
+	StatusCode    int
+	Method        string
+	Endpoint      string
+	ErrorMessages []string
+	Errors        map[string]string
+	Body          string
+	// RetryAfter is the delay requested by the server, if any
+	RetryAfter time.Duration
+}
+
+func newAPIError(method, endpoint string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+	}
+
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && (len(payload.ErrorMessages) > 0 || len(payload.Errors) > 0) {
+		apiErr.ErrorMessages = payload.ErrorMessages
+		apiErr.Errors = payload.Errors
+	} else {
+		apiErr.Body = strings.TrimSpace(string(body))
+	}
+
+	if wait, ok := serverDelay(resp, time.Now()); ok {
+		apiErr.RetryAfter = wait
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	var parts []string
+	parts = append(parts, e.ErrorMessages...)
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+
+	if len(parts) == 0 && e.Body != "" {
+		parts = append(parts, e.Body)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, http.StatusText(e.StatusCode))
+	}
+
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, strings.Join(parts, "; "))
+}
+
+// AsAPIError unwraps err to an *APIError if it contains one.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, status int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == status
+}
+
+// IsUnauthorized reports whether Jira rejected the credentials (401).
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether the user lacks permission (403).
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether the requested resource does not exist (404).
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether Jira throttled the request (429).
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
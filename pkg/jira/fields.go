@@ -0,0 +1,188 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldMeta describes an issue field as returned by the transitions,
+// createmeta and editmeta endpoints.
+type FieldMeta struct {
+	Key             string         `json:"key,omitempty"`
+	FieldID         string         `json:"fieldId,omitempty"` // Used instead of Key by Jira 9+ createmeta
+	Name            string         `json:"name"`
+	Required        bool           `json:"required"`
+	HasDefaultValue bool           `json:"hasDefaultValue,omitempty"`
+	Schema          FieldSchema    `json:"schema"`
+	AllowedValues   []AllowedValue `json:"allowedValues,omitempty"`
+	Operations      []string       `json:"operations,omitempty"`
+}
+
+type FieldSchema struct {
+	Type     string `json:"type"`
+	Items    string `json:"items,omitempty"`
+	System   string `json:"system,omitempty"`
+	Custom   string `json:"custom,omitempty"`
+	CustomID int    `json:"customId,omitempty"`
+}
+
+// AllowedValue is one option of a select-like field. Depending on the field
+// type Jira fills Name (priority, resolution, version) or Value (custom
+// select lists).
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+func (v AllowedValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+// Options returns the labels of the allowed values, in Jira's order.
+func (m FieldMeta) Options() []string {
+	options := make([]string, 0, len(m.AllowedValues))
+	for _, value := range m.AllowedValues {
+		options = append(options, value.Label())
+	}
+	return options
+}
+
+// Value converts text typed or picked by the user into the JSON value Jira
+// expects for this field. Arrays are entered comma separated, dates as
+// YYYY-MM-DD and select fields by option label.
+func (m FieldMeta) Value(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+
+	if m.Schema.Type == "array" {
+		values := []interface{}{}
+		for _, part := range strings.Split(input, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			item := m
+			item.Schema = FieldSchema{Type: m.Schema.Items, System: m.Schema.System, Custom: m.Schema.Custom}
+			value, err := item.Value(part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	if len(m.AllowedValues) > 0 {
+		for _, allowed := range m.AllowedValues {
+			if strings.EqualFold(allowed.Label(), input) || allowed.ID == input {
+				return map[string]string{"id": allowed.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not an allowed value for %s", input, m.Name)
+	}
+
+	switch m.Schema.Type {
+	case "number":
+		number, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", m.Name)
+		}
+		return number, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", input); err != nil {
+			return nil, fmt.Errorf("%s must be a date like 2024-12-31", m.Name)
+		}
+		return input, nil
+	case "user":
+		// Cloud and Server identify users differently, see UserValue
+		return nil, fmt.Errorf("pick %s from the user search", m.Name)
+	case "priority", "resolution", "issuetype", "version", "component", "option":
+		return map[string]string{"name": input}, nil
+	}
+
+	return input, nil
+}
+
+// IsUser reports whether the field takes users, one or several.
+func (m FieldMeta) IsUser() bool {
+	return m.Schema.Type == "user" || m.Schema.Type == "array" && m.Schema.Items == "user"
+}
+
+// UserValue is the value of this field holding user, a list of one for
+// multi-user fields. The client sends the user the way the instance
+// identifies users, see User.FieldValue.
+func (m FieldMeta) UserValue(user User) interface{} {
+	if m.Schema.Type == "array" {
+		return []interface{}{fieldUser{user}}
+	}
+	return fieldUser{user}
+}
+
+// Field is a system or custom field of the Jira instance.
+type Field struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// epicLinkType is the custom field type of the Epic Link field that links
+// issues to their epic on Jira Server and in older Cloud projects.
+const epicLinkType = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+// EpicLinkField returns the ID of the Epic Link field among fields, or ""
+// when the instance has none.
+func EpicLinkField(fields []Field) string {
+	for _, field := range fields {
+		if field.Schema.Custom == epicLinkType {
+			return field.ID
+		}
+	}
+	return ""
+}
+
+// FieldID returns the ID of the field named name among fields, ignoring
+// case, or "" when there is none. Custom fields win over system fields of
+// the same name.
+func FieldID(fields []Field, name string) string {
+	id := ""
+	for _, field := range fields {
+		if !strings.EqualFold(field.Name, name) {
+			continue
+		}
+		if field.Custom {
+			return field.ID
+		}
+		if id == "" {
+			id = field.ID
+		}
+	}
+	return id
+}
+
+func (c *Client) GetFields() ([]Field, error) {
+	return c.GetFieldsContext(context.Background())
+}
+
+// GetFieldsContext lists every field of the Jira instance. Custom fields
+// have instance-specific IDs, this finds them by type or name.
+func (c *Client) GetFieldsContext(ctx context.Context) ([]Field, error) {
+	body, err := c.makeRequest(ctx, "GET", "/rest/api/2/field", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting fields: %w", err)
+	}
+
+	var fields []Field
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("parsing fields response: %w", err)
+	}
+
+	return fields, nil
+}
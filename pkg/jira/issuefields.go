@@ -0,0 +1,215 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Epic is the epic of an issue as the agile API sends it with issues of
+// boards whose projects use epics.
+type Epic struct {
+	ID      int    `json:"id"`
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Summary string `json:"summary,omitempty"`
+	Done    bool   `json:"done,omitempty"`
+}
+
+// IssueRef is an issue as Jira embeds it in another with a few of its
+// fields, such as the parent or the sub-tasks of an issue. The parent is
+// the story of a sub-task or, on Jira Cloud and in team-managed projects,
+// the epic of an issue.
+type IssueRef struct {
+	ID     string         `json:"id,omitempty"`
+	Key    string         `json:"key"`
+	Fields IssueRefFields `json:"fields"`
+}
+
+type IssueRefFields struct {
+	Summary   string     `json:"summary"`
+	Status    Status     `json:"status"`
+	Priority  *Priority  `json:"priority,omitempty"`
+	IssueType *IssueType `json:"issuetype,omitempty"`
+}
+
+// IsEpic reports whether issues of this type are epics.
+func (t *IssueType) IsEpic() bool {
+	return t != nil && (t.HierarchyLevel == 1 || t.Name == "Epic")
+}
+
+// IsSubtask reports whether issues of this type are sub-tasks.
+func (t *IssueType) IsSubtask() bool {
+	return t != nil && (t.Subtask || t.HierarchyLevel == -1)
+}
+
+// SubtaskProgress counts the sub-tasks of an issue and how many of them are
+// done.
+func (f IssueFields) SubtaskProgress() (done, total int) {
+	for _, subtask := range f.Subtasks {
+		if subtask.Fields.Status.Done() {
+			done++
+		}
+	}
+	return done, len(f.Subtasks)
+}
+
+// EpicOf returns the epic of an issue, from the first of these that is set:
+// the epic the agile API adds to issues, a parent that is an epic, or the
+// legacy Epic Link custom field linkField, which only holds the epic's key.
+// It returns nil for issues without an epic.
+func (f IssueFields) EpicOf(linkField string) *Epic {
+	if f.Epic != nil {
+		epic := *f.Epic
+		return &epic
+	}
+	if f.Parent != nil && f.Parent.Fields.IssueType.IsEpic() {
+		id, _ := strconv.Atoi(f.Parent.ID)
+		return &Epic{
+			ID:      id,
+			Key:     f.Parent.Key,
+			Name:    f.Parent.Fields.Summary,
+			Summary: f.Parent.Fields.Summary,
+			Done:    f.Parent.Fields.Status.Done(),
+		}
+	}
+	if linkField == "" {
+		return nil
+	}
+	var key string
+	if err := json.Unmarshal(f.Extra[linkField], &key); err != nil || key == "" {
+		return nil
+	}
+	return &Epic{Key: key}
+}
+
+// issueFieldNames are the JSON names of the fields IssueFields decodes
+// itself; all others end up in Extra.
+var issueFieldNames = jsonNames(reflect.TypeOf(IssueFields{}))
+
+func jsonNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	f.Extra = nil
+	for id, value := range all {
+		if issueFieldNames[id] || string(value) == "null" {
+			continue
+		}
+		if f.Extra == nil {
+			f.Extra = make(map[string]json.RawMessage)
+		}
+		f.Extra[id] = value
+	}
+	return nil
+}
+
+// MarshalJSON writes Extra back next to the other fields, so issues keep
+// their custom fields through a round trip.
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	type plain IssueFields
+	data, err := json.Marshal(plain(f))
+	if err != nil || len(f.Extra) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for id, value := range f.Extra {
+		if !issueFieldNames[id] {
+			all[id] = value
+		}
+	}
+	return json.Marshal(all)
+}
+
+// Text returns a field from Extra as text, whatever its type: numbers and
+// strings as they are, options, users and teams by name or value, arrays
+// comma separated and checkboxes as "yes". It reports false for fields that
+// are unset, empty or false.
+func (f IssueFields) Text(fieldID string) (string, bool) {
+	value, ok := f.Extra[fieldID]
+	if !ok {
+		return "", false
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return "", false
+	}
+	text := fieldText(decoded)
+	return text, text != ""
+}
+
+func fieldText(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		if value {
+			return "yes"
+		}
+	case []interface{}:
+		var parts []string
+		for _, item := range value {
+			if text := fieldText(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"value", "name", "displayName", "title", "key"} {
+			if text, ok := value[key].(string); ok && text != "" {
+				if child := fieldText(value["child"]); child != "" {
+					// Cascading selects hold the second level as child
+					return text + " / " + child
+				}
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// Number returns a numeric field from Extra, such as story points. Numbers
+// sent as strings are accepted as well.
+func (f IssueFields) Number(fieldID string) (float64, bool) {
+	value, ok := f.Extra[fieldID]
+	if !ok {
+		return 0, false
+	}
+
+	var number float64
+	if err := json.Unmarshal(value, &number); err == nil {
+		return number, true
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,194 @@
+package jiratest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// AddBoard registers board details such as the board's project.
+func (s *Store) AddBoard(board jira.Board) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.boards[strconv.Itoa(board.ID)] = board
+}
+
+// Board returns a registered board, or a scrum board without a project for
+// IDs that only have sprints.
+func (s *Store) Board(boardID string) (jira.Board, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if board, ok := s.boards[boardID]; ok {
+		return clone(board), true
+	}
+	if _, ok := s.sprints[boardID]; ok {
+		id, _ := strconv.Atoi(boardID)
+		return jira.Board{ID: id, Name: "Board " + boardID, Type: "scrum"}, true
+	}
+	return jira.Board{}, false
+}
+
+// MoveToSprint moves issues into a sprint, out of the backlog or another
+// sprint. Sub-tasks move along with their parent.
+func (s *Store) MoveToSprint(sprintID int, keys []string) error {
+	if _, ok := s.Sprint(sprintID); !ok {
+		return notFound("Sprint %d does not exist", sprintID)
+	}
+	return s.move(sprintID, keys)
+}
+
+// MoveToBacklog takes issues out of their sprint, along with their
+// sub-tasks.
+func (s *Store) MoveToBacklog(keys []string) error {
+	return s.move(0, keys)
+}
+
+func (s *Store) move(sprintID int, keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if _, issue := s.find(key); issue == nil {
+			return &jira.APIError{
+				StatusCode:    http.StatusBadRequest,
+				ErrorMessages: []string{"Issue " + key + " does not exist or you do not have permission to see it."},
+			}
+		}
+	}
+	for _, key := range s.withSubtasks(keys) {
+		_, issue := s.find(key)
+		moved := *issue
+		s.remove(key)
+		s.issues[sprintID] = append(s.issues[sprintID], moved)
+	}
+	return nil
+}
+
+// SetBoardConfiguration registers the setup of a board along with the JQL
+// of its saved filter. Without filter JQL the board keeps selecting the
+// issues of its sprints.
+func (s *Store) SetBoardConfiguration(boardID string, configuration jira.BoardConfiguration, filterJQL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.boardConfigs[boardID] = clone(configuration)
+	if configuration.Filter.ID != "" && filterJQL != "" {
+		s.filters[configuration.Filter.ID] = filterJQL
+	}
+}
+
+// BoardConfiguration returns the configuration of a board, by default a
+// filter with the board's ID and no sub-filter.
+func (s *Store) BoardConfiguration(boardID string) (jira.BoardConfiguration, bool) {
+	board, ok := s.Board(boardID)
+	if !ok {
+		return jira.BoardConfiguration{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if configuration, ok := s.boardConfigs[boardID]; ok {
+		return clone(configuration), true
+	}
+	return jira.BoardConfiguration{
+		ID:     board.ID,
+		Name:   board.Name,
+		Type:   board.Type,
+		Filter: jira.BoardFilter{ID: boardID},
+	}, true
+}
+
+// BoardIssues returns the issues of a board's filter narrowed by jql.
+// Boards whose filter has no JQL registered select the issues of their
+// sprints.
+func (s *Store) BoardIssues(boardID, jql string) ([]jira.Issue, error) {
+	configuration, ok := s.BoardConfiguration(boardID)
+	if !ok {
+		return nil, notFound("Board %s does not exist or you do not have permission to see it.", boardID)
+	}
+	narrow, err := parseJQL(jql)
+	if err != nil {
+		return nil, &jira.APIError{StatusCode: http.StatusBadRequest, ErrorMessages: []string{"Error in the JQL Query: " + err.Error()}}
+	}
+
+	s.mu.RLock()
+	filterJQL, hasFilter := s.filters[configuration.Filter.ID]
+	s.mu.RUnlock()
+
+	var issues []jira.Issue
+	if hasFilter {
+		if issues, err = s.Search(filterJQL); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, sprint := range s.Sprints(boardID) {
+			issues = append(issues, s.SprintIssues(sprint.ID)...)
+		}
+	}
+
+	var result []jira.Issue
+	for _, issue := range issues {
+		sprintID, _ := s.IssueSprint(issue.Key)
+		if narrow(sprintID, issue) {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
+
+// Backlog returns the issues a board selects that are in no sprint, in the
+// order they were added. Epics are left out, like Jira lists them in the
+// epics panel instead. Boards whose filter has no JQL registered select
+// the issues of their project.
+func (s *Store) Backlog(boardID string) ([]jira.Issue, error) {
+	configuration, ok := s.BoardConfiguration(boardID)
+	if !ok {
+		return nil, notFound("Board %s does not exist or you do not have permission to see it.", boardID)
+	}
+	board, _ := s.Board(boardID)
+
+	s.mu.RLock()
+	filterJQL, hasFilter := s.filters[configuration.Filter.ID]
+	s.mu.RUnlock()
+
+	var issues []jira.Issue
+	if hasFilter {
+		selected, err := s.Search(filterJQL)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range selected {
+			if sprintID, _ := s.IssueSprint(issue.Key); sprintID == 0 {
+				issues = append(issues, issue)
+			}
+		}
+	} else if board.Location != nil {
+		for _, issue := range s.SprintIssues(0) {
+			if project, _, _ := strings.Cut(issue.Key, "-"); project == board.Location.ProjectKey {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	var backlog []jira.Issue
+	for _, issue := range issues {
+		if !issue.Fields.IssueType.IsEpic() {
+			backlog = append(backlog, issue)
+		}
+	}
+	return backlog, nil
+}
+
+// checkSprints fails like Jira when sprints are requested from a Kanban
+// board.
+func (s *Store) checkSprints(boardID string) error {
+	if board, ok := s.Board(boardID); ok && board.Type == "kanban" {
+		return &jira.APIError{StatusCode: http.StatusBadRequest, ErrorMessages: []string{"The board does not support sprints"}}
+	}
+	return nil
+}
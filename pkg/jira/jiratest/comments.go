@@ -0,0 +1,106 @@
+package jiratest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// AddComment appends a comment by the API user to an issue.
+func (s *Store) AddComment(key, body string) (jira.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, issue := s.find(key)
+	if issue == nil {
+		return jira.Comment{}, notFound("Issue %s does not exist", key)
+	}
+	if body == "" {
+		return jira.Comment{}, &jira.APIError{
+			StatusCode: http.StatusBadRequest,
+			Errors:     map[string]string{"comment": "Comment body can not be empty!"},
+		}
+	}
+
+	if issue.Fields.Comment == nil {
+		issue.Fields.Comment = &jira.CommentBlock{}
+	}
+	s.commentSeq++
+	now := time.Now().Format(jiraTime)
+	comment := jira.Comment{
+		ID:   strconv.Itoa(90000 + s.commentSeq),
+		Body: body,
+		Author: jira.CommentUser{
+			Name:        apiUser.Name,
+			Key:         apiUser.Key,
+			DisplayName: apiUser.DisplayName,
+		},
+		Created: now,
+		Updated: now,
+	}
+	issue.Fields.Comment.Comments = append(issue.Fields.Comment.Comments, comment)
+	issue.Fields.Updated = now
+
+	return comment, nil
+}
+
+// UpdateComment replaces the body of one of the API user's comments.
+func (s *Store) UpdateComment(key, commentID, body string) (jira.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, err := s.ownComment(key, commentID)
+	if err != nil {
+		return jira.Comment{}, err
+	}
+	comment.Body = body
+	comment.Updated = time.Now().Format(jiraTime)
+
+	return *comment, nil
+}
+
+// DeleteComment removes one of the API user's comments.
+func (s *Store) DeleteComment(key, commentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.ownComment(key, commentID); err != nil {
+		return err
+	}
+	_, issue := s.find(key)
+	comments := issue.Fields.Comment.Comments
+	for i := range comments {
+		if comments[i].ID == commentID {
+			issue.Fields.Comment.Comments = append(comments[:i:i], comments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ownComment finds a comment the API user may change, failing like Jira
+// does for other people's comments. Must be called with s.mu held.
+func (s *Store) ownComment(key, commentID string) (*jira.Comment, error) {
+	_, issue := s.find(key)
+	if issue == nil {
+		return nil, notFound("Issue %s does not exist", key)
+	}
+	if issue.Fields.Comment != nil {
+		for i := range issue.Fields.Comment.Comments {
+			comment := &issue.Fields.Comment.Comments[i]
+			if comment.ID != commentID {
+				continue
+			}
+			if !apiUser.Is(comment.Author) {
+				return nil, &jira.APIError{
+					StatusCode:    http.StatusForbidden,
+					ErrorMessages: []string{"You do not have the permission to edit this comment."},
+				}
+			}
+			return comment, nil
+		}
+	}
+	return nil, notFound("Can not find a comment for the id: %s.", commentID)
+}
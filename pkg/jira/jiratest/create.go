@@ -0,0 +1,181 @@
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// SetCreateMeta sets the issue types that can be created in a project.
+func (s *Store) SetCreateMeta(projectKey string, issueTypes []jira.IssueTypeMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createMeta[projectKey] = clone(issueTypes)
+}
+
+// CreateMeta returns the creatable issue types of a project; ok is false for
+// unknown projects.
+func (s *Store) CreateMeta(projectKey string) ([]jira.IssueTypeMeta, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	issueTypes, ok := s.createMeta[projectKey]
+	return clone(issueTypes), ok
+}
+
+// createInput is the subset of create fields the Store understands. Other
+// fields are validated against the create metadata and dropped.
+type createInput struct {
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	IssueType struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"issuetype"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	DueDate     string `json:"duedate"`
+	Priority    *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"priority"`
+	Assignee *struct {
+		Name      string `json:"name"`
+		AccountID string `json:"accountId"`
+	} `json:"assignee"`
+}
+
+// CreateIssue creates an issue in the backlog from fields in the format of
+// POST /rest/api/2/issue, validating them like Jira against the project's
+// create metadata.
+func (s *Store) CreateIssue(fields map[string]interface{}) (jira.Issue, error) {
+	// Round-trip through JSON so typed values and decoded requests look alike
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return jira.Issue{}, err
+	}
+	var values map[string]interface{}
+	var input createInput
+	json.Unmarshal(raw, &values)
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return jira.Issue{}, &jira.APIError{StatusCode: http.StatusBadRequest, ErrorMessages: []string{err.Error()}}
+	}
+
+	issueType, errs := s.validateCreate(input, values)
+	if len(errs) > 0 {
+		return jira.Issue{}, &jira.APIError{StatusCode: http.StatusBadRequest, Errors: errs}
+	}
+
+	now := time.Now().Format(jiraTime)
+	issue := jira.Issue{
+		Fields: jira.IssueFields{
+			Summary:     input.Summary,
+			Description: input.Description,
+			DueDate:     input.DueDate,
+			Status:      jira.Status{Name: "Open"},
+			Created:     now,
+			Updated:     now,
+			IssueType:   &jira.IssueType{Name: issueType.Name},
+			Reporter:    &jira.Reporter{Name: apiUser.Name, DisplayName: apiUser.DisplayName},
+			Comment:     &jira.CommentBlock{},
+		},
+		Changelog: &jira.Changelog{},
+	}
+	if input.Priority != nil {
+		issue.Fields.Priority = &jira.Priority{Name: input.Priority.Name}
+		for _, allowed := range issueType.Fields["priority"].AllowedValues {
+			if allowed.ID == input.Priority.ID {
+				issue.Fields.Priority.Name = allowed.Label()
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.statuses) > 0 {
+		issue.Fields.Status = s.status(s.statuses[0])
+	}
+	if input.Assignee != nil {
+		for _, user := range s.assignable() {
+			if (input.Assignee.Name != "" && user.Name == input.Assignee.Name) || (input.Assignee.AccountID != "" && user.AccountID == input.Assignee.AccountID) {
+				issue.Fields.Assignee = &jira.Assignee{Name: user.Name, AccountID: user.AccountID, DisplayName: user.DisplayName}
+			}
+		}
+	}
+	issue.Key = s.nextKey(input.Project.Key)
+	s.issues[0] = append(s.issues[0], clone(issue))
+
+	return issue, nil
+}
+
+// validateCreate checks the fields against the create metadata and returns
+// the issue type, or Jira-style per-field errors.
+func (s *Store) validateCreate(input createInput, values map[string]interface{}) (jira.IssueTypeMeta, map[string]string) {
+	issueTypes, ok := s.CreateMeta(input.Project.Key)
+	if !ok {
+		return jira.IssueTypeMeta{}, map[string]string{"project": "valid project is required"}
+	}
+
+	var issueType *jira.IssueTypeMeta
+	for i := range issueTypes {
+		if issueTypes[i].ID == input.IssueType.ID || (input.IssueType.ID == "" && strings.EqualFold(issueTypes[i].Name, input.IssueType.Name)) {
+			issueType = &issueTypes[i]
+		}
+	}
+	if issueType == nil {
+		return jira.IssueTypeMeta{}, map[string]string{"issuetype": "valid issue type is required"}
+	}
+
+	errs := map[string]string{}
+	for id, field := range issueType.Fields {
+		if value, ok := values[id]; field.Required && !field.HasDefaultValue && (!ok || value == nil || value == "") {
+			errs[id] = field.Name + " is required."
+		}
+	}
+	for id := range values {
+		if _, ok := issueType.Fields[id]; !ok && id != "project" && id != "issuetype" {
+			errs[id] = fmt.Sprintf("Field '%s' cannot be set. It is not on the appropriate screen, or unknown.", id)
+		}
+	}
+	if input.Assignee != nil && input.Assignee.Name != "" {
+		s.mu.RLock()
+		found := false
+		for _, user := range s.assignable() {
+			found = found || user.Name == input.Assignee.Name
+		}
+		s.mu.RUnlock()
+		if !found {
+			errs["assignee"] = "User '" + input.Assignee.Name + "' cannot be assigned issues."
+		}
+	}
+	if input.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", input.DueDate); err != nil {
+			errs["duedate"] = "Error parsing date string: " + input.DueDate
+		}
+	}
+	return *issueType, errs
+}
+
+// nextKey returns the next free issue key of a project. Must be called with
+// s.mu held.
+func (s *Store) nextKey(project string) string {
+	highest := 0
+	for _, issues := range s.issues {
+		for _, issue := range issues {
+			if number, ok := strings.CutPrefix(issue.Key, project+"-"); ok {
+				if n, err := strconv.Atoi(number); err == nil && n > highest {
+					highest = n
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%s-%d", project, highest+1)
+}
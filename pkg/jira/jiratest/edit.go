@@ -0,0 +1,189 @@
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// defaultEditMeta is the edit screen of issues whose project has no create
+// metadata.
+var defaultEditMeta = map[string]jira.FieldMeta{
+	"summary":     {Name: "Summary", Required: true, Schema: jira.FieldSchema{Type: "string", System: "summary"}},
+	"description": {Name: "Description", Schema: jira.FieldSchema{Type: "string", System: "description"}},
+	"duedate":     {Name: "Due Date", Schema: jira.FieldSchema{Type: "date", System: "duedate"}},
+	"labels":      {Name: "Labels", Schema: jira.FieldSchema{Type: "array", Items: "string", System: "labels"}},
+}
+
+// EditMeta returns the editable fields of an issue: the create screen of its
+// project and issue type without project and issue type, or a basic set of
+// system fields.
+func (s *Store) EditMeta(key string) (map[string]jira.FieldMeta, error) {
+	issue, ok := s.Issue(key)
+	if !ok {
+		return nil, notFound("Issue %s does not exist", key)
+	}
+
+	project, _, _ := strings.Cut(key, "-")
+	issueTypes, _ := s.CreateMeta(project)
+	for _, issueType := range issueTypes {
+		if issue.Fields.IssueType == nil || !strings.EqualFold(issueType.Name, issue.Fields.IssueType.Name) {
+			continue
+		}
+		fields := make(map[string]jira.FieldMeta)
+		for id, field := range issueType.Fields {
+			if id != "project" && id != "issuetype" {
+				fields[id] = field
+			}
+		}
+		return fields, nil
+	}
+	return clone(defaultEditMeta), nil
+}
+
+// EditIssue sets fields of an issue in the format of PUT
+// /rest/api/2/issue/{key}, validating them like Jira. Fields the Store does
+// not model are validated against the edit metadata and dropped.
+func (s *Store) EditIssue(key string, fields map[string]interface{}) error {
+	meta, err := s.EditMeta(key)
+	if err != nil {
+		return err
+	}
+
+	// Round-trip through JSON so typed values and decoded requests look alike
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return &jira.APIError{StatusCode: http.StatusBadRequest, ErrorMessages: []string{err.Error()}}
+	}
+
+	errs := map[string]string{}
+	var edits []func(issue *jira.Issue) jira.HistoryItem
+	for id, value := range values {
+		field, ok := meta[id]
+		if !ok {
+			errs[id] = fmt.Sprintf("Field '%s' cannot be set. It is not on the appropriate screen, or unknown.", id)
+			continue
+		}
+		edit, err := s.editField(id, field, value)
+		if err != "" {
+			errs[id] = err
+			continue
+		}
+		if edit != nil {
+			edits = append(edits, edit)
+		}
+	}
+	if len(errs) > 0 {
+		return &jira.APIError{StatusCode: http.StatusBadRequest, Errors: errs}
+	}
+
+	now := time.Now().Format(jiraTime)
+	s.UpdateIssue(key, func(issue *jira.Issue) {
+		history := jira.History{
+			Created: now,
+			Author:  jira.Author{Name: apiUser.Name, DisplayName: apiUser.DisplayName},
+		}
+		for _, edit := range edits {
+			if item := edit(issue); item.FromString != item.ToString {
+				history.Items = append(history.Items, item)
+			}
+		}
+		if len(history.Items) > 0 {
+			if issue.Changelog == nil {
+				issue.Changelog = &jira.Changelog{}
+			}
+			issue.Changelog.Histories = append(issue.Changelog.Histories, history)
+		}
+		issue.Fields.Updated = now
+	})
+	return nil
+}
+
+// editField validates one field value and returns the change to apply, or
+// Jira's error message for the field.
+func (s *Store) editField(id string, field jira.FieldMeta, value json.RawMessage) (func(issue *jira.Issue) jira.HistoryItem, string) {
+	var text *string
+	var ref *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	switch id {
+	case "summary", "description", "duedate":
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, "Operation value must be a string"
+		}
+		if id == "summary" && (text == nil || strings.TrimSpace(*text) == "") {
+			return nil, "You must specify a summary of the issue."
+		}
+		if id == "duedate" && text != nil {
+			if _, err := time.Parse("2006-01-02", *text); err != nil {
+				return nil, "Error parsing date string: " + *text
+			}
+		}
+		return func(issue *jira.Issue) jira.HistoryItem {
+			target := map[string]*string{
+				"summary":     &issue.Fields.Summary,
+				"description": &issue.Fields.Description,
+				"duedate":     &issue.Fields.DueDate,
+			}[id]
+			item := jira.HistoryItem{Field: id, FieldType: "jira", FromString: *target}
+			*target = ""
+			if text != nil {
+				*target = *text
+			}
+			item.ToString = *target
+			return item
+		}, ""
+
+	case "labels":
+		var labels []string
+		if err := json.Unmarshal(value, &labels); err != nil {
+			return nil, "Operation value must be an array of strings"
+		}
+		for _, label := range labels {
+			if strings.ContainsAny(label, " \t") {
+				return nil, fmt.Sprintf("The label '%s' contains spaces which is invalid.", label)
+			}
+		}
+		return func(issue *jira.Issue) jira.HistoryItem {
+			item := jira.HistoryItem{Field: "labels", FieldType: "jira", FromString: strings.Join(issue.Fields.Labels, " ")}
+			issue.Fields.Labels = labels
+			item.ToString = strings.Join(labels, " ")
+			return item
+		}, ""
+
+	case "priority":
+		if err := json.Unmarshal(value, &ref); err != nil || ref == nil {
+			return nil, "Priority name or id is required"
+		}
+		name := ref.Name
+		for _, allowed := range field.AllowedValues {
+			if allowed.ID == ref.ID {
+				name = allowed.Label()
+			}
+		}
+		if name == "" {
+			return nil, "Could not find valid 'id' or 'name' in priority object."
+		}
+		return func(issue *jira.Issue) jira.HistoryItem {
+			item := jira.HistoryItem{Field: "priority", FieldType: "jira", ToString: name}
+			if issue.Fields.Priority != nil {
+				item.FromString = issue.Fields.Priority.Name
+			}
+			issue.Fields.Priority = &jira.Priority{Name: name}
+			return item
+		}, ""
+	}
+
+	// Fields the Store does not model are accepted and dropped
+	return nil, ""
+}
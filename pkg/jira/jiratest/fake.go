@@ -0,0 +1,389 @@
+package jiratest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// Fake is an in-memory jira.API backed by a Store.
+type Fake struct {
+	Store *Store
+
+	mu     sync.Mutex
+	auth   jira.Authenticator
+	errors map[string]error
+	calls  []string
+}
+
+var _ jira.API = (*Fake)(nil)
+
+func NewFake(store *Store) *Fake {
+	return &Fake{
+		Store:  store,
+		errors: make(map[string]error),
+	}
+}
+
+// FailWith makes every call of the named method (e.g.
+// "GetActiveSprintsContext") return err; a nil err clears the failure.
+func (f *Fake) FailWith(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errors, method)
+		return
+	}
+	f.errors[method] = err
+}
+
+// Calls returns the names of the methods called so far, in order.
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.calls...)
+}
+
+// call records the method and returns its injected or context error.
+func (f *Fake) call(ctx context.Context, method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, method)
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return f.errors[method]
+}
+
+func (f *Fake) SetAuthenticator(auth jira.Authenticator) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = auth
+}
+
+func (f *Fake) GetBoardContext(ctx context.Context, boardID string) (*jira.Board, error) {
+	if err := f.call(ctx, "GetBoardContext"); err != nil {
+		return nil, err
+	}
+	board, ok := f.Store.Board(boardID)
+	if !ok {
+		return nil, notFound("Board %s does not exist", boardID)
+	}
+	return &board, nil
+}
+
+func (f *Fake) GetStatusesContext(ctx context.Context) ([]jira.Status, error) {
+	if err := f.call(ctx, "GetStatusesContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.Statuses(), nil
+}
+
+func (f *Fake) GetFieldsContext(ctx context.Context) ([]jira.Field, error) {
+	if err := f.call(ctx, "GetFieldsContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.Fields(), nil
+}
+
+func (f *Fake) GetBoardConfigurationContext(ctx context.Context, boardID string) (*jira.BoardConfiguration, error) {
+	if err := f.call(ctx, "GetBoardConfigurationContext"); err != nil {
+		return nil, err
+	}
+	configuration, ok := f.Store.BoardConfiguration(boardID)
+	if !ok {
+		return nil, notFound("Board %s does not exist", boardID)
+	}
+	return &configuration, nil
+}
+
+func (f *Fake) GetBoardIssuesContext(ctx context.Context, boardID, jql string) ([]jira.Issue, error) {
+	if err := f.call(ctx, "GetBoardIssuesContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.BoardIssues(boardID, jql)
+}
+
+func (f *Fake) GetBoardBacklogContext(ctx context.Context, boardID string) ([]jira.Issue, error) {
+	if err := f.call(ctx, "GetBoardBacklogContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.Backlog(boardID)
+}
+
+func (f *Fake) GetActiveSprintsContext(ctx context.Context, boardID string) ([]jira.Sprint, error) {
+	if err := f.call(ctx, "GetActiveSprintsContext"); err != nil {
+		return nil, err
+	}
+	if err := f.Store.checkSprints(boardID); err != nil {
+		return nil, err
+	}
+	return f.Store.Sprints(boardID, "active"), nil
+}
+
+func (f *Fake) GetBoardSprintsContext(ctx context.Context, boardID string) ([]jira.Sprint, error) {
+	if err := f.call(ctx, "GetBoardSprintsContext"); err != nil {
+		return nil, err
+	}
+	if err := f.Store.checkSprints(boardID); err != nil {
+		return nil, err
+	}
+	return f.Store.Sprints(boardID), nil
+}
+
+func (f *Fake) GetSprintDetailsContext(ctx context.Context, sprintID int) (*jira.Sprint, error) {
+	if err := f.call(ctx, "GetSprintDetailsContext"); err != nil {
+		return nil, err
+	}
+	sprint, ok := f.Store.Sprint(sprintID)
+	if !ok {
+		return nil, notFound("Sprint %d does not exist", sprintID)
+	}
+	return &sprint, nil
+}
+
+func (f *Fake) GetSprintIssuesViaJQLContext(ctx context.Context, sprintID int) ([]jira.Issue, error) {
+	if err := f.call(ctx, "GetSprintIssuesViaJQLContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.SprintIssues(sprintID), nil
+}
+
+func (f *Fake) GetIssueHistoryContext(ctx context.Context, issueKey string) (*jira.Issue, error) {
+	if err := f.call(ctx, "GetIssueHistoryContext"); err != nil {
+		return nil, err
+	}
+	return f.issue(issueKey)
+}
+
+func (f *Fake) SearchIssueContext(ctx context.Context, issueKey string) (*jira.Issue, error) {
+	if err := f.call(ctx, "SearchIssueContext"); err != nil {
+		return nil, err
+	}
+	return f.issue(issueKey)
+}
+
+func (f *Fake) SearchContext(ctx context.Context, jql string) ([]jira.Issue, error) {
+	if err := f.call(ctx, "SearchContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.Search(jql)
+}
+
+func (f *Fake) GetIssuesContext(ctx context.Context, keys []string) ([]jira.Issue, error) {
+	if err := f.call(ctx, "GetIssuesContext"); err != nil {
+		return nil, err
+	}
+	var issues []jira.Issue
+	for _, key := range keys {
+		if issue, ok := f.Store.Issue(key); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+func (f *Fake) GetTransitionsContext(ctx context.Context, issueKey string) ([]jira.Transition, error) {
+	if err := f.call(ctx, "GetTransitionsContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.Transitions(issueKey)
+}
+
+func (f *Fake) DoTransitionContext(ctx context.Context, issueKey, transitionID string, fields map[string]interface{}) error {
+	if err := f.call(ctx, "DoTransitionContext"); err != nil {
+		return err
+	}
+	return f.Store.Transition(issueKey, transitionID, fields)
+}
+
+func (f *Fake) MyselfContext(ctx context.Context) (*jira.User, error) {
+	if err := f.call(ctx, "MyselfContext"); err != nil {
+		return nil, err
+	}
+	user := f.Store.Myself()
+	return &user, nil
+}
+
+func (f *Fake) FindAssignableUsersContext(ctx context.Context, issueKey, query string) ([]jira.User, error) {
+	if err := f.call(ctx, "FindAssignableUsersContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.AssignableUsers(issueKey, query)
+}
+
+func (f *Fake) FindProjectAssignableUsersContext(ctx context.Context, projectKey, query string) ([]jira.User, error) {
+	if err := f.call(ctx, "FindProjectAssignableUsersContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.ProjectAssignableUsers(projectKey, query)
+}
+
+func (f *Fake) AssignIssueContext(ctx context.Context, issueKey string, user *jira.User) error {
+	if err := f.call(ctx, "AssignIssueContext"); err != nil {
+		return err
+	}
+	return f.Store.Assign(issueKey, user)
+}
+
+func (f *Fake) GetCreateMetaContext(ctx context.Context, projectKey string) ([]jira.IssueTypeMeta, error) {
+	if err := f.call(ctx, "GetCreateMetaContext"); err != nil {
+		return nil, err
+	}
+	issueTypes, ok := f.Store.CreateMeta(projectKey)
+	if !ok {
+		return nil, fmt.Errorf("no create metadata for project %s", projectKey)
+	}
+	return issueTypes, nil
+}
+
+func (f *Fake) CreateIssueContext(ctx context.Context, fields map[string]interface{}) (*jira.CreatedIssue, error) {
+	if err := f.call(ctx, "CreateIssueContext"); err != nil {
+		return nil, err
+	}
+	issue, err := f.Store.CreateIssue(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &jira.CreatedIssue{Key: issue.Key}, nil
+}
+
+func (f *Fake) MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error {
+	if err := f.call(ctx, "MoveIssuesToSprintContext"); err != nil {
+		return err
+	}
+	return f.Store.MoveToSprint(sprintID, issueKeys)
+}
+
+func (f *Fake) MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error {
+	if err := f.call(ctx, "MoveIssuesToBacklogContext"); err != nil {
+		return err
+	}
+	return f.Store.MoveToBacklog(issueKeys)
+}
+
+func (f *Fake) CreateSprintContext(ctx context.Context, boardID string, sprint jira.Sprint) (*jira.Sprint, error) {
+	if err := f.call(ctx, "CreateSprintContext"); err != nil {
+		return nil, err
+	}
+	created, err := f.Store.CreateSprint(boardID, sprint)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (f *Fake) StartSprintContext(ctx context.Context, sprintID int, start, end time.Time) (*jira.Sprint, error) {
+	if err := f.call(ctx, "StartSprintContext"); err != nil {
+		return nil, err
+	}
+	state, startDate, endDate := "active", start.Format(jiraTime), end.Format(jiraTime)
+	sprint, err := f.Store.UpdateSprint(sprintID, SprintUpdate{State: &state, StartDate: &startDate, EndDate: &endDate})
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// CompleteSprintContext moves the issues of the sprint whose status is not
+// in the done category on, then closes the sprint.
+func (f *Fake) CompleteSprintContext(ctx context.Context, sprintID, moveTo int) ([]string, error) {
+	if err := f.call(ctx, "CompleteSprintContext"); err != nil {
+		return nil, err
+	}
+	var incomplete []string
+	for _, issue := range f.Store.SprintIssues(sprintID) {
+		if !issue.Fields.Status.Done() {
+			incomplete = append(incomplete, issue.Key)
+		}
+	}
+	if len(incomplete) > 0 {
+		var err error
+		if moveTo == 0 {
+			err = f.Store.MoveToBacklog(incomplete)
+		} else {
+			err = f.Store.MoveToSprint(moveTo, incomplete)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	state := "closed"
+	if _, err := f.Store.UpdateSprint(sprintID, SprintUpdate{State: &state}); err != nil {
+		return incomplete, err
+	}
+	return incomplete, nil
+}
+
+func (f *Fake) GetEditMetaContext(ctx context.Context, issueKey string) (map[string]jira.FieldMeta, error) {
+	if err := f.call(ctx, "GetEditMetaContext"); err != nil {
+		return nil, err
+	}
+	return f.Store.EditMeta(issueKey)
+}
+
+func (f *Fake) UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	if err := f.call(ctx, "UpdateIssueContext"); err != nil {
+		return err
+	}
+	return f.Store.EditIssue(issueKey, fields)
+}
+
+func (f *Fake) AddCommentContext(ctx context.Context, issueKey, body string) (*jira.Comment, error) {
+	if err := f.call(ctx, "AddCommentContext"); err != nil {
+		return nil, err
+	}
+	comment, err := f.Store.AddComment(issueKey, body)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (f *Fake) UpdateCommentContext(ctx context.Context, issueKey, commentID, body string) (*jira.Comment, error) {
+	if err := f.call(ctx, "UpdateCommentContext"); err != nil {
+		return nil, err
+	}
+	comment, err := f.Store.UpdateComment(issueKey, commentID, body)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (f *Fake) DeleteCommentContext(ctx context.Context, issueKey, commentID string) error {
+	if err := f.call(ctx, "DeleteCommentContext"); err != nil {
+		return err
+	}
+	return f.Store.DeleteComment(issueKey, commentID)
+}
+
+func (f *Fake) issue(key string) (*jira.Issue, error) {
+	issue, ok := f.Store.Issue(key)
+	if !ok {
+		return nil, notFound("Issue %s does not exist", key)
+	}
+	return &issue, nil
+}
+
+// notFound builds the error a live server would produce for a missing resource.
+func badRequest(format string, args ...interface{}) *jira.APIError {
+	return &jira.APIError{
+		StatusCode:    http.StatusBadRequest,
+		ErrorMessages: []string{fmt.Sprintf(format, args...)},
+	}
+}
+
+func notFound(format string, args ...interface{}) *jira.APIError {
+	return &jira.APIError{
+		StatusCode:    http.StatusNotFound,
+		ErrorMessages: []string{fmt.Sprintf(format, args...)},
+	}
+}
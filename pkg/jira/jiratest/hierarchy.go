@@ -0,0 +1,135 @@
+package jiratest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// linkRefs fills in the parent, the sub-tasks and the linked issues of
+// issues from the stored issues, the way Jira embeds them: the parent and
+// linked issues with their current fields, and as sub-tasks the stored
+// sub-tasks naming the issue as their parent. It must be called with s.mu
+// held.
+func (s *Store) linkRefs(issues []jira.Issue) []jira.Issue {
+	stored := make(map[string]*jira.Issue)
+	subtasks := make(map[string][]jira.IssueRef)
+	for _, sprintIssues := range s.issues {
+		for i := range sprintIssues {
+			issue := &sprintIssues[i]
+			stored[issue.Key] = issue
+			if issue.Fields.Parent != nil && issue.Fields.IssueType.IsSubtask() {
+				subtasks[issue.Fields.Parent.Key] = append(subtasks[issue.Fields.Parent.Key], issueRef(*issue, ""))
+			}
+		}
+	}
+
+	for i := range issues {
+		fields := &issues[i].Fields
+		if fields.Parent != nil {
+			if parent := stored[fields.Parent.Key]; parent != nil {
+				ref := issueRef(*parent, fields.Parent.ID)
+				fields.Parent = &ref
+			}
+		}
+		for j := range fields.IssueLinks {
+			link := &fields.IssueLinks[j]
+			for _, ref := range []**jira.IssueRef{&link.InwardIssue, &link.OutwardIssue} {
+				if *ref == nil {
+					continue
+				}
+				if linked := stored[(*ref).Key]; linked != nil {
+					updated := issueRef(*linked, (*ref).ID)
+					*ref = &updated
+				}
+			}
+		}
+		fields.Subtasks = subtasks[issues[i].Key]
+		sort.Slice(fields.Subtasks, func(a, b int) bool {
+			return keyLess(fields.Subtasks[a].Key, fields.Subtasks[b].Key)
+		})
+	}
+	return issues
+}
+
+// issueRef embeds issue in another, with its clone's fields.
+func issueRef(issue jira.Issue, id string) jira.IssueRef {
+	issue = clone(issue)
+	return jira.IssueRef{
+		ID:  id,
+		Key: issue.Key,
+		Fields: jira.IssueRefFields{
+			Summary:   issue.Fields.Summary,
+			Status:    issue.Fields.Status,
+			Priority:  issue.Fields.Priority,
+			IssueType: issue.Fields.IssueType,
+		},
+	}
+}
+
+// keyLess orders issue keys by project, then by number.
+func keyLess(a, b string) bool {
+	projectA, numberA, _ := strings.Cut(a, "-")
+	projectB, numberB, _ := strings.Cut(b, "-")
+	if projectA != projectB {
+		return projectA < projectB
+	}
+	x, _ := strconv.Atoi(numberA)
+	y, _ := strconv.Atoi(numberB)
+	return x < y
+}
+
+// withSubtasks adds the keys of the stored sub-tasks of issues to keys, as
+// Jira moves sub-tasks along with their parent. It must be called with s.mu
+// held.
+func (s *Store) withSubtasks(keys []string) []string {
+	moved := make(map[string]bool)
+	for _, key := range keys {
+		moved[key] = true
+	}
+
+	result := append([]string(nil), keys...)
+	for _, sprintIssues := range s.issues {
+		for _, issue := range sprintIssues {
+			parent := issue.Fields.Parent
+			if parent != nil && moved[parent.Key] && !moved[issue.Key] && issue.Fields.IssueType.IsSubtask() {
+				moved[issue.Key] = true
+				result = append(result, issue.Key)
+			}
+		}
+	}
+	return result
+}
+
+// LinkIssues links two stored issues with a link of the given type, read as
+// "from <linkType.Outward> to", and adds the link to both of them as Jira
+// does.
+func (s *Store) LinkIssues(linkType jira.IssueLinkType, from, to string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, outward := s.find(from)
+	if outward == nil {
+		return notFound("Issue %s does not exist", from)
+	}
+	_, inward := s.find(to)
+	if inward == nil {
+		return notFound("Issue %s does not exist", to)
+	}
+
+	s.linkSeq++
+	id := strconv.Itoa(10000 + s.linkSeq)
+	outward.Fields.IssueLinks = append(outward.Fields.IssueLinks, jira.IssueLink{
+		ID:           id,
+		Type:         linkType,
+		OutwardIssue: &jira.IssueRef{ID: id, Key: to},
+	})
+	inward.Fields.IssueLinks = append(inward.Fields.IssueLinks, jira.IssueLink{
+		ID:          id,
+		Type:        linkType,
+		InwardIssue: &jira.IssueRef{ID: id, Key: from},
+	})
+	return nil
+}
@@ -0,0 +1,283 @@
+package jiratest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// issueFilter decides whether an issue, located in the given sprint,
+// matches a parsed JQL query.
+type issueFilter func(sprintID int, issue jira.Issue) bool
+
+var (
+	orderByPattern  = regexp.MustCompile(`(?i)\s+order\s+by\s+.*$`)
+	andPattern      = regexp.MustCompile(`(?i)\s+and\s+`)
+	orPattern       = regexp.MustCompile(`(?i)\s+or\s+`)
+	clausePattern   = regexp.MustCompile(`(?i)^\s*("[^"]+"|[\w.]+)\s*(!=|>=|<=|=|>|<|\s+in\s+)\s*(.+?)\s*$`)
+	relativePattern = regexp.MustCompile(`^-(\d+)([dwh])$`)
+)
+
+// Search returns the issues matching jql, in sprint order. Queries outside
+// the subset parseJQL understands fail like invalid JQL in Jira.
+func (s *Store) Search(jql string) ([]jira.Issue, error) {
+	filter, err := parseJQL(jql)
+	if err != nil {
+		return nil, &jira.APIError{StatusCode: http.StatusBadRequest, ErrorMessages: []string{"Error in the JQL Query: " + err.Error()}}
+	}
+
+	var result []jira.Issue
+	for _, issue := range s.Issues() {
+		sprintID, _ := s.IssueSprint(issue.Key)
+		if filter(sprintID, issue) {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
+
+// parseJQL understands the small subset of JQL used by the client, the
+// fixtures and the demo: "field = value", "field != value" and "field in
+// (a, b)" clauses on sprint, key, status, statusCategory, project,
+// assignee, reporter, priority and issue type, and "created" or "updated"
+// compared with <, <=, > or >= to a date or a relative "-14d". Clauses are
+// joined by AND and OR and grouped with parentheses. currentUser() is the
+// API user. ORDER BY is accepted and ignored.
+func parseJQL(jql string) (issueFilter, error) {
+	jql = orderByPattern.ReplaceAllString(strings.TrimSpace(jql), "")
+	if strings.HasPrefix(strings.ToLower(jql), "order by") {
+		jql = ""
+	}
+	if jql == "" {
+		return func(int, jira.Issue) bool { return true }, nil
+	}
+
+	if alternatives := splitTopLevel(jql, orPattern); len(alternatives) > 1 {
+		var filters []issueFilter
+		for _, alternative := range alternatives {
+			filter, err := parseJQL(alternative)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+		return func(sprintID int, issue jira.Issue) bool {
+			for _, filter := range filters {
+				if filter(sprintID, issue) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+
+	var filters []issueFilter
+	for _, clause := range splitTopLevel(jql, andPattern) {
+		clause = strings.TrimSpace(clause)
+		if grouped(clause) {
+			filter, err := parseJQL(clause[1 : len(clause)-1])
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+			continue
+		}
+
+		m := clausePattern.FindStringSubmatch(clause)
+		if m == nil {
+			return nil, fmt.Errorf("unsupported JQL clause %q", clause)
+		}
+		field := strings.ToLower(unquote(m[1]))
+		operator := strings.ToLower(strings.TrimSpace(m[2]))
+		if strings.ContainsAny(operator, "<>") {
+			filter, err := compareDate(field, operator, unquote(m[3]))
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+			continue
+		}
+		values := parseValues(m[3], operator == "in")
+
+		value, err := fieldGetter(field)
+		if err != nil {
+			return nil, err
+		}
+		negate := operator == "!="
+		filters = append(filters, func(sprintID int, issue jira.Issue) bool {
+			actual := value(sprintID, issue)
+			for _, want := range values {
+				if strings.EqualFold(want, "currentUser()") {
+					want = apiUser.Name
+				}
+				if strings.EqualFold(actual, want) {
+					return !negate
+				}
+			}
+			return negate
+		})
+	}
+
+	return func(sprintID int, issue jira.Issue) bool {
+		for _, filter := range filters {
+			if !filter(sprintID, issue) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// splitTopLevel splits jql at the matches of separator outside of
+// parentheses.
+func splitTopLevel(jql string, separator *regexp.Regexp) []string {
+	var parts []string
+	start := 0
+	for _, match := range separator.FindAllStringIndex(jql, -1) {
+		if match[0] < start || depth(jql[:match[0]]) != 0 {
+			continue
+		}
+		parts = append(parts, jql[start:match[0]])
+		start = match[1]
+	}
+	return append(parts, jql[start:])
+}
+
+// depth returns the number of parentheses left open in jql.
+func depth(jql string) int {
+	return strings.Count(jql, "(") - strings.Count(jql, ")")
+}
+
+// grouped reports whether clause is wrapped in a pair of parentheses.
+func grouped(clause string) bool {
+	if !strings.HasPrefix(clause, "(") || !strings.HasSuffix(clause, ")") {
+		return false
+	}
+	inner := clause[1 : len(clause)-1]
+	for i := range inner {
+		if depth(inner[:i+1]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareDate filters by the created or updated date of issues, compared
+// with a date like 2024-12-31 or a time relative to now like -14d.
+func compareDate(field, operator, raw string) (issueFilter, error) {
+	var date func(issue jira.Issue) string
+	switch field {
+	case "created":
+		date = func(issue jira.Issue) string { return issue.Fields.Created }
+	case "updated":
+		date = func(issue jira.Issue) string { return issue.Fields.Updated }
+	default:
+		return nil, fmt.Errorf("field %q cannot be compared by the fake server", field)
+	}
+
+	var bound time.Time
+	if m := relativePattern.FindStringSubmatch(raw); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour, "h": time.Hour}[m[2]]
+		bound = time.Now().Add(-time.Duration(n) * unit)
+	} else {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("date value %q is not supported by the fake server", raw)
+		}
+		bound = parsed
+	}
+
+	return func(_ int, issue jira.Issue) bool {
+		at, err := time.Parse(jiraTime, date(issue))
+		if err != nil {
+			return false
+		}
+		switch operator {
+		case "<":
+			return at.Before(bound)
+		case "<=":
+			return !at.After(bound)
+		case ">":
+			return at.After(bound)
+		}
+		return !at.Before(bound)
+	}, nil
+}
+
+func fieldGetter(field string) (func(sprintID int, issue jira.Issue) string, error) {
+	switch field {
+	case "sprint":
+		return func(sprintID int, _ jira.Issue) string { return strconv.Itoa(sprintID) }, nil
+	case "key", "issuekey", "id":
+		return func(_ int, issue jira.Issue) string { return issue.Key }, nil
+	case "status":
+		return func(_ int, issue jira.Issue) string { return issue.Fields.Status.Name }, nil
+	case "statuscategory":
+		return func(_ int, issue jira.Issue) string {
+			if issue.Fields.Status.StatusCategory == nil {
+				return "EMPTY"
+			}
+			return issue.Fields.Status.StatusCategory.Name
+		}, nil
+	case "project":
+		return func(_ int, issue jira.Issue) string {
+			project, _, _ := strings.Cut(issue.Key, "-")
+			return project
+		}, nil
+	case "assignee":
+		return func(_ int, issue jira.Issue) string {
+			if issue.Fields.Assignee == nil {
+				return "EMPTY"
+			}
+			return issue.Fields.Assignee.Name
+		}, nil
+	case "reporter":
+		return func(_ int, issue jira.Issue) string {
+			if issue.Fields.Reporter == nil {
+				return "EMPTY"
+			}
+			return issue.Fields.Reporter.Name
+		}, nil
+	case "priority":
+		return func(_ int, issue jira.Issue) string {
+			if issue.Fields.Priority == nil {
+				return "EMPTY"
+			}
+			return issue.Fields.Priority.Name
+		}, nil
+	case "type", "issuetype":
+		return func(_ int, issue jira.Issue) string {
+			if issue.Fields.IssueType == nil {
+				return "EMPTY"
+			}
+			return issue.Fields.IssueType.Name
+		}, nil
+	}
+	return nil, fmt.Errorf("field %q is not supported by the fake server", field)
+}
+
+func parseValues(raw string, list bool) []string {
+	raw = strings.TrimSpace(raw)
+	if !list {
+		return []string{unquote(raw)}
+	}
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "("), ")")
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		values = append(values, unquote(strings.TrimSpace(value)))
+	}
+	return values
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
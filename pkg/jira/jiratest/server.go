@@ -0,0 +1,501 @@
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+const defaultMaxResults = 50
+
+// Server is a fake Jira serving the agile and search REST endpoints from a
+// Store. Credentials are accepted without checking.
+type Server struct {
+	*httptest.Server
+	Store *Store
+}
+
+// NewServer starts a fake Jira on a local port. Call Close when done.
+func NewServer(store *Store) *Server {
+	s := &Server{Store: store}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewClient returns a jira.Client talking to the server, with client-side
+// rate limiting and retries disabled so failures surface immediately.
+func (s *Server) NewClient() *jira.Client {
+	client := jira.NewClient("jiratest", "jiratest", s.URL)
+	client.SetRateLimit(0, 0)
+	client.SetRetryPolicy(jira.RetryPolicy{MaxAttempts: 1})
+	return client
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "rest" {
+		writeError(w, http.StatusNotFound, "No resource at %s", r.URL.Path)
+		return
+	}
+
+	switch segments[1] {
+	case "agile":
+		s.serveAgile(w, r, segments[3:])
+	case "api":
+		// /rest/api/2/... and /rest/api/latest/... are the same resources
+		s.serveAPI(w, r, segments[3:])
+	case "auth":
+		s.serveAuth(w, r, segments[3:])
+	default:
+		writeError(w, http.StatusNotFound, "No resource at %s", r.URL.Path)
+	}
+}
+
+func (s *Server) serveAgile(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	// board/{id}/sprint
+	case len(path) == 3 && path[0] == "board" && path[2] == "sprint" && r.Method == http.MethodGet:
+		var states []string
+		if state := r.URL.Query().Get("state"); state != "" {
+			states = strings.Split(state, ",")
+		}
+		if err := s.Store.checkSprints(path[1]); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writePage(w, r, s.Store.Sprints(path[1], states...), true)
+
+	// board/{id}/configuration
+	case len(path) == 3 && path[0] == "board" && path[2] == "configuration" && r.Method == http.MethodGet:
+		configuration, ok := s.Store.BoardConfiguration(path[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, "Board %s does not exist or you do not have permission to see it.", path[1])
+			return
+		}
+		writeJSON(w, http.StatusOK, configuration)
+
+	// board/{id}/issue
+	case len(path) == 3 && path[0] == "board" && path[2] == "issue" && r.Method == http.MethodGet:
+		issues, err := s.Store.BoardIssues(path[1], r.URL.Query().Get("jql"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writePage(w, r, issues, false)
+
+	// board/{id}/backlog
+	case len(path) == 3 && path[0] == "board" && path[2] == "backlog" && r.Method == http.MethodGet:
+		issues, err := s.Store.Backlog(path[1])
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writePage(w, r, issues, false)
+
+	// board/{id}/sprint/{sprintId}/issue
+	case len(path) == 5 && path[0] == "board" && path[2] == "sprint" && path[4] == "issue" && r.Method == http.MethodGet:
+		sprintID, err := strconv.Atoi(path[3])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid sprint ID %s", path[3])
+			return
+		}
+		writePage(w, r, s.Store.SprintIssues(sprintID), false)
+
+	// board/{id}
+	case len(path) == 2 && path[0] == "board" && r.Method == http.MethodGet:
+		board, ok := s.Store.Board(path[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, "Board %s does not exist or you do not have permission to see it.", path[1])
+			return
+		}
+		writeJSON(w, http.StatusOK, board)
+
+	// sprint/{id}/issue
+	case len(path) == 3 && path[0] == "sprint" && path[2] == "issue" && r.Method == http.MethodPost:
+		sprintID, err := strconv.Atoi(path[1])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid sprint ID %s", path[1])
+			return
+		}
+		var request struct {
+			Issues []string `json:"issues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		if err := s.Store.MoveToSprint(sprintID, request.Issues); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	// sprint
+	case len(path) == 1 && path[0] == "sprint" && r.Method == http.MethodPost:
+		var request struct {
+			jira.Sprint
+			OriginBoardID int `json:"originBoardId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		sprint, err := s.Store.CreateSprint(strconv.Itoa(request.OriginBoardID), request.Sprint)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, sprint)
+
+	// sprint/{id}, a partial update
+	case len(path) == 2 && path[0] == "sprint" && r.Method == http.MethodPost:
+		sprintID, err := strconv.Atoi(path[1])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid sprint ID %s", path[1])
+			return
+		}
+		var update SprintUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		sprint, err := s.Store.UpdateSprint(sprintID, update)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sprint)
+
+	// backlog/issue
+	case len(path) == 2 && path[0] == "backlog" && path[1] == "issue" && r.Method == http.MethodPost:
+		var request struct {
+			Issues []string `json:"issues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		if err := s.Store.MoveToBacklog(request.Issues); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	// sprint/{id}
+	case len(path) == 2 && path[0] == "sprint" && r.Method == http.MethodGet:
+		sprintID, err := strconv.Atoi(path[1])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid sprint ID %s", path[1])
+			return
+		}
+		sprint, ok := s.Store.Sprint(sprintID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "Sprint %d does not exist", sprintID)
+			return
+		}
+		writeJSON(w, http.StatusOK, sprint)
+
+	default:
+		writeError(w, http.StatusNotFound, "No agile resource at %s", r.URL.Path)
+	}
+}
+
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "search" && r.Method == http.MethodGet:
+		issues, err := s.Store.Search(r.URL.Query().Get("jql"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writePage(w, r, issues, false)
+
+	case len(path) == 1 && path[0] == "status" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.Store.Statuses())
+
+	case len(path) == 1 && path[0] == "field" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.Store.Fields())
+
+	case len(path) == 1 && path[0] == "serverInfo" && r.Method == http.MethodGet:
+		info := s.Store.ServerInfo()
+		info.BaseURL = s.URL
+		writeJSON(w, http.StatusOK, info)
+
+	case len(path) == 1 && path[0] == "myself" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.Store.Myself())
+
+	case len(path) == 3 && path[0] == "user" && path[1] == "assignable" && path[2] == "search" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		search := query.Get("username")
+		if search == "" {
+			search = query.Get("query")
+		}
+		var users []jira.User
+		var err error
+		if project := query.Get("project"); project != "" {
+			users, err = s.Store.ProjectAssignableUsers(project, search)
+		} else {
+			users, err = s.Store.AssignableUsers(query.Get("issueKey"), search)
+		}
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, users)
+
+	// issue/{key}/assignee
+	case len(path) == 3 && path[0] == "issue" && path[2] == "assignee" && r.Method == http.MethodPut:
+		var request struct {
+			Name      *string `json:"name"`
+			AccountID *string `json:"accountId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		var user *jira.User
+		switch {
+		case request.AccountID != nil:
+			user = &jira.User{AccountID: *request.AccountID}
+		case request.Name != nil:
+			user = &jira.User{Name: *request.Name}
+		}
+		if err := s.Store.Assign(path[1], user); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[0] == "issue" && path[1] == "createmeta" && r.Method == http.MethodGet:
+		projects := []interface{}{}
+		for _, key := range strings.Split(r.URL.Query().Get("projectKeys"), ",") {
+			if issueTypes, ok := s.Store.CreateMeta(key); ok {
+				projects = append(projects, map[string]interface{}{"key": key, "issuetypes": issueTypes})
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"projects": projects})
+
+	case len(path) == 1 && path[0] == "issue" && r.Method == http.MethodPost:
+		var request struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		issue, err := s.Store.CreateIssue(request.Fields)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, jira.CreatedIssue{
+			ID:   issue.Key,
+			Key:  issue.Key,
+			Self: s.URL + "/rest/api/2/issue/" + issue.Key,
+		})
+
+	// issue/{key}/transitions
+	case len(path) == 3 && path[0] == "issue" && path[2] == "transitions":
+		s.serveTransitions(w, r, path[1])
+
+	// issue/{key}/comment[/{id}]
+	case len(path) >= 3 && len(path) <= 4 && path[0] == "issue" && path[2] == "comment":
+		commentID := ""
+		if len(path) == 4 {
+			commentID = path[3]
+		}
+		s.serveComment(w, r, path[1], commentID)
+
+	// issue/{key}/editmeta
+	case len(path) == 3 && path[0] == "issue" && path[2] == "editmeta" && r.Method == http.MethodGet:
+		fields, err := s.Store.EditMeta(path[1])
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fields": fields})
+
+	// issue/{key}
+	case len(path) == 2 && path[0] == "issue" && r.Method == http.MethodPut:
+		var request struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		if err := s.Store.EditIssue(path[1], request.Fields); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	// issue/{key}
+	case len(path) == 2 && path[0] == "issue" && r.Method == http.MethodGet:
+		issue, ok := s.Store.Issue(path[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+			return
+		}
+		writeJSON(w, http.StatusOK, issue)
+
+	default:
+		writeError(w, http.StatusNotFound, "No API resource at %s", r.URL.Path)
+	}
+}
+
+func (s *Server) serveTransitions(w http.ResponseWriter, r *http.Request, key string) {
+	switch r.Method {
+	case http.MethodGet:
+		transitions, err := s.Store.Transitions(key)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"transitions": transitions})
+
+	case http.MethodPost:
+		var request struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+		if err := s.Store.Transition(key, request.Transition.ID, request.Fields); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method %s not allowed", r.Method)
+	}
+}
+
+func (s *Server) serveComment(w http.ResponseWriter, r *http.Request, key, commentID string) {
+	var request struct {
+		Body string `json:"body"`
+	}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: %v", err)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && commentID == "":
+		comment, err := s.Store.AddComment(key, request.Body)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, comment)
+
+	case r.Method == http.MethodPut && commentID != "":
+		comment, err := s.Store.UpdateComment(key, commentID, request.Body)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, comment)
+
+	case r.Method == http.MethodDelete && commentID != "":
+		if err := s.Store.DeleteComment(key, commentID); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method %s not allowed", r.Method)
+	}
+}
+
+func (s *Server) serveAuth(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 1 && path[0] == "session" && r.Method == http.MethodPost {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"session": map[string]string{"name": "JSESSIONID", "value": "jiratest"},
+		})
+		return
+	}
+	writeError(w, http.StatusNotFound, "No auth resource at %s", r.URL.Path)
+}
+
+// writePage slices items according to startAt/maxResults and wraps them in
+// Jira's paging envelope, under "values" for agile lists or "issues".
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, values bool) {
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, err := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if err != nil || maxResults <= 0 || maxResults > defaultMaxResults {
+		maxResults = defaultMaxResults
+	}
+
+	if startAt < 0 || startAt > len(items) {
+		startAt = len(items)
+	}
+	end := startAt + maxResults
+	if end > len(items) {
+		end = len(items)
+	}
+	page := items[startAt:end]
+	if page == nil {
+		page = []T{}
+	}
+
+	body := map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(items),
+		"isLast":     end >= len(items),
+	}
+	if values {
+		body["values"] = page
+	} else {
+		body["issues"] = page
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeAPIError renders an error from the Store the way Jira would.
+func writeAPIError(w http.ResponseWriter, err error) {
+	apiErr, ok := jira.AsAPIError(err)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	errorMessages := apiErr.ErrorMessages
+	if errorMessages == nil {
+		errorMessages = []string{}
+	}
+	errors := apiErr.Errors
+	if errors == nil {
+		errors = map[string]string{}
+	}
+	writeJSON(w, apiErr.StatusCode, map[string]interface{}{
+		"errorMessages": errorMessages,
+		"errors":        errors,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"errorMessages": []string{fmt.Sprintf(format, args...)},
+		"errors":        map[string]string{},
+	})
+}
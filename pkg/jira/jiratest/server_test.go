@@ -0,0 +1,90 @@
+package jiratest
+
+import (
+	"context"
+	"testing"
+)
+
+// TestServerFixtures reads the fixture file through a real jira.Client, so
+// the fixture format cannot drift from what the client decodes.
+func TestServerFixtures(t *testing.T) {
+	store, err := LoadFixtures("testdata/sprint_board.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(store)
+	defer server.Close()
+	client := server.NewClient()
+	ctx := context.Background()
+
+	board, err := client.GetBoardContext(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetBoardContext: %v", err)
+	}
+	if board.ID != 123 || board.Type != "scrum" {
+		t.Errorf("board is %+v, want scrum board 123", board)
+	}
+
+	sprints, err := client.GetBoardSprintsContext(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetBoardSprintsContext: %v", err)
+	}
+	if len(sprints) != 2 || sprints[0].Name != "Sprint 9" || sprints[1].State != "active" {
+		t.Errorf("sprints are %+v, want Sprint 9 and the active Sprint 10", sprints)
+	}
+	active, err := client.GetActiveSprintsContext(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetActiveSprintsContext: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != 11 || active[0].EndDate != "2024-04-28T17:00:00.000Z" {
+		t.Errorf("active sprints are %+v, want sprint 11", active)
+	}
+
+	issues, err := client.GetSprintIssuesViaJQLContext(ctx, 11)
+	if err != nil {
+		t.Fatalf("GetSprintIssuesViaJQLContext: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("sprint 11 has %d issues, want 3", len(issues))
+	}
+	byKey := make(map[string]int)
+	for i, issue := range issues {
+		byKey[issue.Key] = i
+	}
+	bug := issues[byKey["DEV-2"]]
+	if bug.Fields.Status.Name != "In Progress" || bug.Fields.Assignee == nil || bug.Fields.Assignee.DisplayName != "Bob Jones" {
+		t.Errorf("DEV-2 fields are %+v", bug.Fields)
+	}
+	if bug.Fields.Priority == nil || bug.Fields.Priority.Name != "High" || bug.Fields.IssueType == nil || bug.Fields.IssueType.Name != "Bug" {
+		t.Errorf("DEV-2 has priority %+v and type %+v, want a High Bug", bug.Fields.Priority, bug.Fields.IssueType)
+	}
+	if bug.Changelog == nil || len(bug.Changelog.Histories) != 1 || bug.Changelog.Histories[0].Items[0].ToString != "In Progress" {
+		t.Errorf("DEV-2 changelog is %+v, want the move to In Progress", bug.Changelog)
+	}
+	story := issues[byKey["DEV-3"]]
+	if story.Fields.Assignee != nil {
+		t.Errorf("DEV-3 is assigned to %+v, want unassigned", story.Fields.Assignee)
+	}
+	if story.Fields.Comment == nil || len(story.Fields.Comment.Comments) != 1 || story.Fields.Comment.Comments[0].Body != "PR is up" {
+		t.Errorf("DEV-3 comments are %+v, want the fixture comment", story.Fields.Comment)
+	}
+
+	found, err := client.SearchContext(ctx, `assignee = alice AND status != Closed`)
+	if err != nil {
+		t.Fatalf("SearchContext: %v", err)
+	}
+	if len(found) != 1 || found[0].Key != "DEV-4" {
+		t.Errorf("search found %d issues %v, want DEV-4", len(found), found)
+	}
+	all, err := client.SearchContext(ctx, "assignee = alice")
+	if err != nil {
+		t.Fatalf("SearchContext: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("alice has %d issues, want DEV-1 and DEV-4", len(all))
+	}
+
+	if _, err := client.GetBoardContext(ctx, "999"); err == nil {
+		t.Error("GetBoardContext found a board missing from the fixtures")
+	}
+}
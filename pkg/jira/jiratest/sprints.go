@@ -0,0 +1,86 @@
+package jiratest
+
+import "jira-boards-tui/pkg/jira"
+
+// SprintUpdate is a partial update of a sprint; nil fields are kept.
+type SprintUpdate struct {
+	Name      *string `json:"name"`
+	State     *string `json:"state"`
+	StartDate *string `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	Goal      *string `json:"goal"`
+}
+
+// CreateSprint adds a future sprint to a board, numbered after the highest
+// sprint ID in use.
+func (s *Store) CreateSprint(boardID string, sprint jira.Sprint) (jira.Sprint, error) {
+	if _, ok := s.Board(boardID); !ok {
+		return jira.Sprint{}, notFound("Board %s does not exist or you do not have permission to see it.", boardID)
+	}
+	if err := s.checkSprints(boardID); err != nil {
+		return jira.Sprint{}, err
+	}
+	if sprint.Name == "" {
+		return jira.Sprint{}, badRequest("The sprint name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sprint.ID = 1
+	for _, sprints := range s.sprints {
+		for _, existing := range sprints {
+			if existing.ID >= sprint.ID {
+				sprint.ID = existing.ID + 1
+			}
+		}
+	}
+	sprint.State = "future"
+	s.sprints[boardID] = append(s.sprints[boardID], sprint)
+	return clone(sprint), nil
+}
+
+// UpdateSprint applies a partial update to a sprint. State changes follow
+// Jira: only future sprints with dates start, and only active ones close.
+func (s *Store) UpdateSprint(sprintID int, update SprintUpdate) (jira.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for boardID, sprints := range s.sprints {
+		for i := range sprints {
+			if sprints[i].ID != sprintID {
+				continue
+			}
+			sprint := sprints[i]
+			if update.Name != nil {
+				sprint.Name = *update.Name
+			}
+			if update.StartDate != nil {
+				sprint.StartDate = *update.StartDate
+			}
+			if update.EndDate != nil {
+				sprint.EndDate = *update.EndDate
+			}
+			if update.Goal != nil {
+				sprint.Goal = *update.Goal
+			}
+
+			if update.State != nil && *update.State != sprint.State {
+				switch {
+				case *update.State == "active" && sprint.State == "future":
+					if sprint.StartDate == "" || sprint.EndDate == "" {
+						return jira.Sprint{}, badRequest("A sprint needs a start and an end date to be started")
+					}
+				case *update.State == "closed" && sprint.State == "active":
+				default:
+					return jira.Sprint{}, badRequest("Sprint %d cannot go from %s to %s", sprintID, sprint.State, *update.State)
+				}
+				sprint.State = *update.State
+			}
+
+			s.sprints[boardID][i] = sprint
+			return clone(sprint), nil
+		}
+	}
+	return jira.Sprint{}, notFound("Sprint %d does not exist", sprintID)
+}
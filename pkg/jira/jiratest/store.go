@@ -0,0 +1,320 @@
+// Package jiratest provides fakes of the Jira API for running board logic
+// without a live server: Fake implements jira.API in memory, Server serves
+// the agile and search REST endpoints over HTTP so the real jira.Client code
+// path is exercised. Both read from a Store, usually loaded from fixture JSON
+// (see testdata/sprint_board.json for the layout).
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// Fixtures is the JSON layout of a fake Jira instance.
+type Fixtures struct {
+	// Sprints per board ID
+	Sprints map[string][]jira.Sprint `json:"sprints"`
+	// Boards lists board details; boards with sprints but no entry are
+	// served as scrum boards without a project
+	Boards []jira.Board `json:"boards,omitempty"`
+	// Issues per sprint ID; sprint 0 is the backlog
+	Issues map[int][]jira.Issue `json:"issues"`
+	// Statuses every issue can be transitioned into; derived from the
+	// issues when empty
+	Statuses []string `json:"statuses,omitempty"`
+	// StatusCategories maps status names to a category key: "new",
+	// "indeterminate" or "done". Statuses without one have no category.
+	StatusCategories map[string]string `json:"statusCategories,omitempty"`
+	// TransitionFields lists the screen fields of transitions into a status
+	TransitionFields map[string]map[string]jira.FieldMeta `json:"transitionFields,omitempty"`
+	// Users that can be assigned issues, besides the API user
+	Users []jira.User `json:"users,omitempty"`
+	// CreateMeta lists the creatable issue types per project key
+	CreateMeta map[string][]jira.IssueTypeMeta `json:"createMeta,omitempty"`
+	// BoardConfigurations per board ID; boards without one are served a
+	// filter named after the board
+	BoardConfigurations map[string]jira.BoardConfiguration `json:"boardConfigurations,omitempty"`
+	// Filters holds the JQL of saved filters per filter ID
+	Filters map[string]string `json:"filters,omitempty"`
+	// Fields of the instance, such as the custom fields issues carry
+	Fields []jira.Field `json:"fields,omitempty"`
+	// Cloud serves the instance as Jira Cloud in serverInfo, as Jira Server
+	// otherwise
+	Cloud bool `json:"cloud,omitempty"`
+}
+
+// Store holds the state of a fake Jira instance. It is safe for concurrent
+// use; every value handed out is a deep copy so callers can mutate freely.
+type Store struct {
+	mu               sync.RWMutex
+	boards           map[string]jira.Board
+	sprints          map[string][]jira.Sprint
+	issues           map[int][]jira.Issue
+	statuses         []string
+	categories       map[string]string
+	transitionFields map[string]map[string]jira.FieldMeta
+	users            []jira.User
+	createMeta       map[string][]jira.IssueTypeMeta
+	boardConfigs     map[string]jira.BoardConfiguration
+	filters          map[string]string
+	fields           []jira.Field
+	cloud            bool
+	commentSeq       int
+	linkSeq          int
+}
+
+func NewStore(fixtures Fixtures) *Store {
+	s := &Store{
+		sprints:          make(map[string][]jira.Sprint),
+		issues:           make(map[int][]jira.Issue),
+		statuses:         append([]string(nil), fixtures.Statuses...),
+		categories:       clone(fixtures.StatusCategories),
+		transitionFields: clone(fixtures.TransitionFields),
+		users:            clone(fixtures.Users),
+		boards:           make(map[string]jira.Board),
+		createMeta:       clone(fixtures.CreateMeta),
+		boardConfigs:     clone(fixtures.BoardConfigurations),
+		filters:          clone(fixtures.Filters),
+		fields:           clone(fixtures.Fields),
+		cloud:            fixtures.Cloud,
+	}
+	if s.createMeta == nil {
+		s.createMeta = make(map[string][]jira.IssueTypeMeta)
+	}
+	if s.categories == nil {
+		s.categories = make(map[string]string)
+	}
+	if s.boardConfigs == nil {
+		s.boardConfigs = make(map[string]jira.BoardConfiguration)
+	}
+	if s.filters == nil {
+		s.filters = make(map[string]string)
+	}
+	for _, board := range fixtures.Boards {
+		s.boards[strconv.Itoa(board.ID)] = clone(board)
+	}
+	if s.transitionFields == nil {
+		s.transitionFields = make(map[string]map[string]jira.FieldMeta)
+	}
+	for boardID, sprints := range fixtures.Sprints {
+		s.sprints[boardID] = clone(sprints)
+	}
+	for sprintID, issues := range fixtures.Issues {
+		s.issues[sprintID] = clone(issues)
+	}
+	return s
+}
+
+// LoadFixtures reads a Fixtures JSON file into a new Store.
+func LoadFixtures(filename string) (*Store, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("parsing fixtures %s: %w", filename, err)
+	}
+
+	return NewStore(fixtures), nil
+}
+
+// Sprints returns the sprints of a board, restricted to the given states
+// ("active", "closed", "future") when any are passed.
+func (s *Store) Sprints(boardID string, states ...string) []jira.Sprint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []jira.Sprint
+	for _, sprint := range s.sprints[boardID] {
+		if len(states) == 0 || contains(states, sprint.State) {
+			result = append(result, sprint)
+		}
+	}
+	return clone(result)
+}
+
+func (s *Store) Sprint(sprintID int) (jira.Sprint, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sprints := range s.sprints {
+		for _, sprint := range sprints {
+			if sprint.ID == sprintID {
+				return sprint, true
+			}
+		}
+	}
+	return jira.Sprint{}, false
+}
+
+func (s *Store) SprintIssues(sprintID int) []jira.Issue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.linkRefs(clone(s.issues[sprintID]))
+}
+
+// Issues returns every issue of every sprint, ordered by sprint ID.
+func (s *Store) Issues() []jira.Issue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sprintIDs := make([]int, 0, len(s.issues))
+	for sprintID := range s.issues {
+		sprintIDs = append(sprintIDs, sprintID)
+	}
+	sort.Ints(sprintIDs)
+
+	var result []jira.Issue
+	for _, sprintID := range sprintIDs {
+		result = append(result, s.issues[sprintID]...)
+	}
+	return s.linkRefs(clone(result))
+}
+
+func (s *Store) Issue(key string) (jira.Issue, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, issue := s.find(key); issue != nil {
+		return s.linkRefs([]jira.Issue{clone(*issue)})[0], true
+	}
+	return jira.Issue{}, false
+}
+
+// IssueSprint returns the ID of the sprint the issue belongs to.
+func (s *Store) IssueSprint(key string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sprintID, issue := s.find(key)
+	return sprintID, issue != nil
+}
+
+// SetWorkflow replaces the statuses issues can be transitioned into.
+func (s *Store) SetWorkflow(statuses []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses = append([]string(nil), statuses...)
+}
+
+// SetStatusCategories sets the category keys of statuses by name.
+func (s *Store) SetStatusCategories(categories map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for status, key := range categories {
+		s.categories[status] = key
+	}
+}
+
+// SetTransitionFields sets the screen fields shown when transitioning an
+// issue into status.
+func (s *Store) SetTransitionFields(status string, fields map[string]jira.FieldMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transitionFields[status] = clone(fields)
+}
+
+// SetFields replaces the fields of the instance.
+func (s *Store) SetFields(fields []jira.Field) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fields = clone(fields)
+}
+
+// Fields returns the fields of the instance.
+func (s *Store) Fields() []jira.Field {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return clone(s.fields)
+}
+
+func (s *Store) AddSprint(boardID string, sprint jira.Sprint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sprints[boardID] = append(s.sprints[boardID], sprint)
+}
+
+// PutIssue adds the issue to a sprint, replacing any issue with the same key.
+func (s *Store) PutIssue(sprintID int, issue jira.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(issue.Key)
+	s.issues[sprintID] = append(s.issues[sprintID], clone(issue))
+}
+
+// UpdateIssue applies update to the stored issue. It reports whether the
+// issue exists.
+func (s *Store) UpdateIssue(key string, update func(issue *jira.Issue)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, issue := s.find(key)
+	if issue == nil {
+		return false
+	}
+	update(issue)
+	return true
+}
+
+// find must be called with s.mu held.
+func (s *Store) find(key string) (int, *jira.Issue) {
+	for sprintID, issues := range s.issues {
+		for i := range issues {
+			if issues[i].Key == key {
+				return sprintID, &issues[i]
+			}
+		}
+	}
+	return 0, nil
+}
+
+// remove must be called with s.mu held.
+func (s *Store) remove(key string) {
+	for sprintID, issues := range s.issues {
+		for i := range issues {
+			if issues[i].Key == key {
+				s.issues[sprintID] = append(issues[:i:i], issues[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// clone deep-copies v through JSON, the same way the values travel over
+// the wire, so no pointer is shared between the store and its callers.
+func clone[T any](v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("jiratest: cloning %T: %v", v, err))
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("jiratest: cloning %T: %v", v, err))
+	}
+	return out
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package jiratest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// jiraTime is the timestamp layout of Jira's REST API.
+const jiraTime = "2006-01-02T15:04:05.000-0700"
+
+// Transitions lists the transitions available for an issue: one into every
+// other status of the workflow. Transition IDs are stable per status.
+func (s *Store) Transitions(key string) ([]jira.Transition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, issue := s.find(key)
+	if issue == nil {
+		return nil, notFound("Issue %s does not exist", key)
+	}
+
+	var transitions []jira.Transition
+	for i, status := range s.workflow() {
+		if status == issue.Fields.Status.Name {
+			continue
+		}
+		transitions = append(transitions, jira.Transition{
+			ID:     strconv.Itoa((i + 1) * 10),
+			Name:   status,
+			To:     s.status(status),
+			Fields: clone(s.transitionFields[status]),
+		})
+	}
+	return transitions, nil
+}
+
+// Transition moves an issue into the status of transitionID, rejecting the
+// request like Jira does when required screen fields are missing.
+func (s *Store) Transition(key, transitionID string, fields map[string]interface{}) error {
+	transitions, err := s.Transitions(key)
+	if err != nil {
+		return err
+	}
+
+	for _, transition := range transitions {
+		if transition.ID != transitionID {
+			continue
+		}
+
+		missing := map[string]string{}
+		for _, id := range transition.RequiredFields() {
+			if value, ok := fields[id]; !ok || value == nil || value == "" {
+				missing[id] = transition.Fields[id].Name + " is required."
+			}
+		}
+		if len(missing) > 0 {
+			return &jira.APIError{StatusCode: http.StatusBadRequest, Errors: missing}
+		}
+
+		now := time.Now().Format(jiraTime)
+		s.UpdateIssue(key, func(issue *jira.Issue) {
+			if issue.Changelog == nil {
+				issue.Changelog = &jira.Changelog{}
+			}
+			issue.Changelog.Histories = append(issue.Changelog.Histories, jira.History{
+				Created: now,
+				Author:  jira.Author{Name: apiUser.Name, DisplayName: apiUser.DisplayName},
+				Items: []jira.HistoryItem{{
+					Field:      "status",
+					FieldType:  "jira",
+					FromString: issue.Fields.Status.Name,
+					ToString:   transition.To.Name,
+				}},
+			})
+			issue.Fields.Status = transition.To
+			issue.Fields.Updated = now
+		})
+		return nil
+	}
+
+	return &jira.APIError{
+		StatusCode:    http.StatusBadRequest,
+		ErrorMessages: []string{"Transition id '" + transitionID + "' is not valid for this issue."},
+	}
+}
+
+// workflow returns the configured statuses, or every status in use. Must be
+// called with s.mu held.
+func (s *Store) workflow() []string {
+	if len(s.statuses) > 0 {
+		return s.statuses
+	}
+
+	seen := map[string]bool{}
+	var statuses []string
+	for _, issues := range s.issues {
+		for _, issue := range issues {
+			if name := issue.Fields.Status.Name; !seen[name] {
+				seen[name] = true
+				statuses = append(statuses, name)
+			}
+		}
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// Statuses lists the statuses of the workflow. IDs follow the workflow
+// order, like the transition IDs.
+func (s *Store) Statuses() []jira.Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var statuses []jira.Status
+	for _, name := range s.workflow() {
+		statuses = append(statuses, s.status(name))
+	}
+	return statuses
+}
+
+// Status returns a status by name as Jira sends it with issues, with its ID
+// and category when known.
+func (s *Store) Status(name string) jira.Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.status(name)
+}
+
+// statusCategories are the categories Jira ships with, by key.
+var statusCategories = map[string]jira.StatusCategory{
+	"new":           {ID: 2, Key: "new", Name: "To Do", ColorName: "blue-gray"},
+	"indeterminate": {ID: 4, Key: "indeterminate", Name: "In Progress", ColorName: "yellow"},
+	"done":          {ID: 3, Key: "done", Name: "Done", ColorName: "green"},
+}
+
+// status must be called with s.mu held.
+func (s *Store) status(name string) jira.Status {
+	status := jira.Status{Name: name}
+	for i, known := range s.workflow() {
+		if known == name {
+			status.ID = strconv.Itoa(i + 1)
+		}
+	}
+	if category, ok := statusCategories[s.categories[name]]; ok {
+		status.StatusCategory = &category
+	}
+	return status
+}
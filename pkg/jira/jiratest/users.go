@@ -0,0 +1,145 @@
+package jiratest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+)
+
+// apiUser is the account the fakes are authenticated as. Changes made
+// through them are recorded under its name.
+var apiUser = jira.User{Name: "jiratest", Key: "jiratest", DisplayName: "Jira Test User", Active: true}
+
+// Myself returns the user the fakes act as.
+func (s *Store) Myself() jira.User {
+	return apiUser
+}
+
+// ServerInfo describes the instance as GET /rest/api/2/serverInfo does.
+func (s *Store) ServerInfo() jira.ServerInfo {
+	info := jira.ServerInfo{Version: "9.12.0", DeploymentType: "Server"}
+	if s.cloud {
+		info = jira.ServerInfo{Version: "1001.0.0", DeploymentType: "Cloud"}
+	}
+	return info
+}
+
+// SetUsers replaces the users issues can be assigned to.
+func (s *Store) SetUsers(users []jira.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = clone(users)
+}
+
+// AssignableUsers returns the users whose name, display name or e-mail
+// starts with query, or any word of the display name does, like Jira's
+// user picker.
+func (s *Store) AssignableUsers(key, query string) ([]jira.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, issue := s.find(key); issue == nil {
+		return nil, notFound("Issue %s does not exist", key)
+	}
+	return s.matchingUsers(query), nil
+}
+
+// ProjectAssignableUsers is AssignableUsers for new issues of a project
+// with create metadata.
+func (s *Store) ProjectAssignableUsers(projectKey, query string) ([]jira.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.createMeta[projectKey]; !ok {
+		return nil, notFound("No project could be found with key '%s'.", projectKey)
+	}
+	return s.matchingUsers(query), nil
+}
+
+// matchingUsers lists the assignable users matching query. Must be called
+// with s.mu held.
+func (s *Store) matchingUsers(query string) []jira.User {
+	query = strings.ToLower(query)
+	users := []jira.User{}
+	for _, user := range s.assignable() {
+		if query == "" || userMatches(user, query) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// Assign sets the assignee of an issue, or clears it for a nil user.
+func (s *Store) Assign(key string, user *jira.User) error {
+	s.mu.RLock()
+	_, issue := s.find(key)
+	var assignee *jira.Assignee
+	if user != nil {
+		for _, candidate := range s.assignable() {
+			if (user.Name != "" && candidate.Name == user.Name) || (user.AccountID != "" && candidate.AccountID == user.AccountID) {
+				assignee = &jira.Assignee{Name: candidate.Name, AccountID: candidate.AccountID, DisplayName: candidate.DisplayName}
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	if issue == nil {
+		return notFound("Issue %s does not exist", key)
+	}
+	if user != nil && assignee == nil {
+		return &jira.APIError{
+			StatusCode: http.StatusBadRequest,
+			Errors:     map[string]string{"assignee": "User '" + user.Name + user.AccountID + "' cannot be assigned issues."},
+		}
+	}
+
+	now := time.Now().Format(jiraTime)
+	s.UpdateIssue(key, func(issue *jira.Issue) {
+		item := jira.HistoryItem{Field: "assignee", FieldType: "jira"}
+		if issue.Fields.Assignee != nil {
+			item.From = issue.Fields.Assignee.Name
+			item.FromString = issue.Fields.Assignee.DisplayName
+		}
+		if assignee != nil {
+			item.To = assignee.Name
+			item.ToString = assignee.DisplayName
+		}
+		if issue.Changelog == nil {
+			issue.Changelog = &jira.Changelog{}
+		}
+		issue.Changelog.Histories = append(issue.Changelog.Histories, jira.History{
+			Created: now,
+			Author:  jira.Author{Name: apiUser.Name, DisplayName: apiUser.DisplayName},
+			Items:   []jira.HistoryItem{item},
+		})
+		issue.Fields.Assignee = assignee
+		issue.Fields.Updated = now
+	})
+	return nil
+}
+
+// assignable lists the configured users and the API user. Must be called
+// with s.mu held.
+func (s *Store) assignable() []jira.User {
+	users := append([]jira.User(nil), s.users...)
+	for _, user := range users {
+		if user.Name == apiUser.Name {
+			return users
+		}
+	}
+	return append(users, apiUser)
+}
+
+func userMatches(user jira.User, query string) bool {
+	candidates := append([]string{user.Name, user.EmailAddress}, strings.Fields(user.DisplayName)...)
+	candidates = append(candidates, user.DisplayName)
+	for _, candidate := range candidates {
+		if candidate != "" && strings.HasPrefix(strings.ToLower(candidate), query) {
+			return true
+		}
+	}
+	return false
+}
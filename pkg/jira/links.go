@@ -0,0 +1,60 @@
+package jira
+
+import "strings"
+
+// IssueLink links an issue to another. Jira sends each link from the side
+// of the issue it belongs to and sets only the other issue: InwardIssue
+// when the other issue is on the inward side of the link type, read with
+// its Inward text (this issue "is blocked by" InwardIssue), OutwardIssue
+// when it is on the outward side (this issue "blocks" OutwardIssue).
+type IssueLink struct {
+	ID           string        `json:"id,omitempty"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *IssueRef     `json:"inwardIssue,omitempty"`
+	OutwardIssue *IssueRef     `json:"outwardIssue,omitempty"`
+}
+
+// IssueLinkType names a kind of link and how it reads from either side.
+type IssueLinkType struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// IsBlocking reports whether links of this type are blocking dependencies,
+// the "Blocks" type Jira ships with.
+func (t IssueLinkType) IsBlocking() bool {
+	return strings.EqualFold(t.Name, "Blocks") || strings.EqualFold(t.Outward, "blocks")
+}
+
+// Describe returns how the link reads from the issue it belongs to, such as
+// "is blocked by", and the other issue.
+func (l IssueLink) Describe() (string, *IssueRef) {
+	if l.InwardIssue != nil {
+		return l.Type.Inward, l.InwardIssue
+	}
+	return l.Type.Outward, l.OutwardIssue
+}
+
+// BlockedBy returns the issues blocking an issue, done or not.
+func (f IssueFields) BlockedBy() []IssueRef {
+	var result []IssueRef
+	for _, link := range f.IssueLinks {
+		if link.Type.IsBlocking() && link.InwardIssue != nil {
+			result = append(result, *link.InwardIssue)
+		}
+	}
+	return result
+}
+
+// Blocking returns the issues an issue blocks.
+func (f IssueFields) Blocking() []IssueRef {
+	var result []IssueRef
+	for _, link := range f.IssueLinks {
+		if link.Type.IsBlocking() && link.OutwardIssue != nil {
+			result = append(result, *link.OutwardIssue)
+		}
+	}
+	return result
+}
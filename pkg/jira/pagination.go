@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const defaultPageSize = 100
+
+// pageResponse is the envelope shared by Jira's paginated list endpoints.
+// Agile endpoints return their items in "values" (sprints, boards) or
+// "issues", the search API always uses "issues".
+type pageResponse[T any] struct {
+	StartAt    int  `json:"startAt"`
+	MaxResults int  `json:"maxResults"`
+	Total      int  `json:"total"`
+	IsLast     bool `json:"isLast"`
+	Values     []T  `json:"values"`
+	Issues     []T  `json:"issues"`
+}
+
+func (p *pageResponse[T]) items() []T {
+	if len(p.Values) > 0 {
+		return p.Values
+	}
+	return p.Issues
+}
+
+// SetPageSize sets how many items are requested per page. Jira may return
+// fewer than requested; pagination follows whatever the server sends.
+func (c *Client) SetPageSize(pageSize int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	c.pageSize = pageSize
+}
+
+// SetMaxResults caps the number of issues listed for a sprint, board,
+// backlog or search. Zero means no cap: every page is fetched. Lookups that
+// must see everything, such as sprint lists and create screens, are never
+// capped.
+func (c *Client) SetMaxResults(maxResults int) {
+	if maxResults < 0 {
+		maxResults = 0
+	}
+	c.maxResults = maxResults
+}
+
+// paginate fetches endpoint page by page, up to the client's result cap.
+// It is meant for issue lists shown to the user; use paginateAll where a
+// missing item would be wrong rather than just not shown.
+func paginate[T any](ctx context.Context, c *Client, endpoint string) ([]T, error) {
+	return fetchPages[T](ctx, c, endpoint, c.maxResults)
+}
+
+// paginateAll fetches every page of endpoint, ignoring the result cap.
+func paginateAll[T any](ctx context.Context, c *Client, endpoint string) ([]T, error) {
+	return fetchPages[T](ctx, c, endpoint, 0)
+}
+
+// fetchPages follows startAt until Jira reports the last page (isLast, total
+// or an empty page) or limit items were fetched. A limit of 0 fetches all.
+func fetchPages[T any](ctx context.Context, c *Client, endpoint string, limit int) ([]T, error) {
+	var all []T
+	startAt := 0
+
+	for {
+		pageSize := c.pageSize
+		if limit > 0 && limit-len(all) < pageSize {
+			pageSize = limit - len(all)
+		}
+
+		body, err := c.makeRequest(ctx, "GET", pageEndpoint(endpoint, startAt, pageSize), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page pageResponse[T]
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("parsing page at %d: %w", startAt, err)
+		}
+
+		items := page.items()
+		all = append(all, items...)
+		startAt += len(items)
+
+		if page.IsLast || len(items) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			break
+		}
+		if limit > 0 && len(all) >= limit {
+			break
+		}
+	}
+
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+	}
+
+	return all, nil
+}
+
+func pageEndpoint(endpoint string, startAt, maxResults int) string {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%sstartAt=%d&maxResults=%d", endpoint, sep, startAt, maxResults)
+}
@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int    // Items the server holds
+		serverMax  int    // Page size the server caps requests to, 0 for none
+		end        string // How the server reports the last page: "isLast", "total" or "empty"
+		key        string // "values" or "issues"
+		maxResults int
+		all        bool // Fetch with paginateAll
+		want       int
+		requests   int
+	}{
+		{name: "isLast", total: 250, end: "isLast", key: "values", want: 250, requests: 3},
+		{name: "total", total: 250, end: "total", key: "issues", want: 250, requests: 3},
+		{name: "empty page", total: 250, end: "empty", key: "values", want: 250, requests: 4},
+		{name: "server pages smaller than requested", total: 120, serverMax: 50, end: "total", key: "issues", want: 120, requests: 3},
+		{name: "result cap", total: 250, end: "total", key: "issues", maxResults: 150, want: 150, requests: 2},
+		{name: "result cap ignored", total: 250, end: "total", key: "values", maxResults: 150, all: true, want: 250, requests: 3},
+		{name: "no items", total: 0, end: "isLast", key: "values", want: 0, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+				size, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+				if tt.serverMax > 0 && size > tt.serverMax {
+					size = tt.serverMax
+				}
+
+				items := []int{}
+				for i := startAt; i < tt.total && len(items) < size; i++ {
+					items = append(items, i)
+				}
+				page := map[string]interface{}{"startAt": startAt, "maxResults": size, tt.key: items}
+				switch tt.end {
+				case "isLast":
+					page["isLast"] = startAt+len(items) >= tt.total
+				case "total":
+					page["total"] = tt.total
+				}
+				json.NewEncoder(w).Encode(page)
+			}))
+			defer server.Close()
+
+			client := NewClient("user", "secret", server.URL)
+			client.SetRateLimit(0, 0)
+			client.SetMaxResults(tt.maxResults)
+
+			fetch := paginate[int]
+			if tt.all {
+				fetch = paginateAll[int]
+			}
+			got, err := fetch(context.Background(), client, "/rest/agile/1.0/board/1/sprint?state=active")
+			if err != nil {
+				t.Fatalf("paginate: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d items, want %d", len(got), tt.want)
+			}
+			for i, item := range got {
+				if item != i {
+					t.Fatalf("item %d is %d, pages were joined out of order", i, item)
+				}
+			}
+			if requests != tt.requests {
+				t.Errorf("made %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestPageEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"/rest/api/2/search", "/rest/api/2/search?startAt=100&maxResults=50"},
+		{"/rest/api/2/search?jql=x", "/rest/api/2/search?jql=x&startAt=100&maxResults=50"},
+	}
+	for _, tt := range tests {
+		if got := pageEndpoint(tt.endpoint, 100, 50); got != tt.want {
+			t.Errorf("pageEndpoint(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
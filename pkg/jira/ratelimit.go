@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRequestsPerSecond = 5
+	defaultBurst             = 10
+)
+
+// rateLimiter is a token bucket shared by all requests of a client. It can
+// additionally be paused when Jira reports that the quota is exhausted.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second, <= 0 disables limiting
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SetRateLimit limits the client to requestsPerSecond with bursts of up to
+// burst requests. A non-positive rate disables client-side limiting.
+func (c *Client) SetRateLimit(requestsPerSecond float64, burst int) {
+	c.limiter = newRateLimiter(requestsPerSecond, burst)
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns how long
+// to wait before trying again.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// pause holds back every request of the client for d, used when Jira asks
+// us to back off so other boards do not keep hitting the limit.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
@@ -0,0 +1,154 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests failing with rate limiting or
+// transient server errors are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one,
+	// 1 disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// SetRetryPolicy replaces the client's retry policy. Zero fields fall back
+// to DefaultRetryPolicy values.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	defaults := DefaultRetryPolicy()
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	c.retry = policy
+}
+
+// backoff returns the exponential delay before the given retry attempt
+// (1-based) with "equal jitter": half fixed, half random.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// shouldRetry reports whether a failed attempt may be repeated. Rate
+// limited requests were turned away unprocessed and are always retried.
+// Gateway errors and network errors are only retried for idempotent
+// methods since the server may already have applied the request, and a
+// repeated POST could create a second issue, comment or sprint.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if resp != nil {
+		return retryableStatus(method, resp.StatusCode)
+	}
+	// Failures before the request was sent, e.g. a rejected session login
+	if apiErr, ok := AsAPIError(err); ok {
+		return retryableStatus(method, apiErr.StatusCode)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return idempotent(method)
+}
+
+func retryableStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+// serverDelay extracts how long the server asked us to wait, from
+// Retry-After or, when the quota is exhausted, X-RateLimit-Reset.
+func serverDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return nonNegative(at.Sub(now)), true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset")); ok {
+			return nonNegative(reset.Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+// parseRateLimitReset accepts the ISO 8601 timestamps sent by Jira Cloud
+// as well as Unix epoch seconds used by some proxies.
+func parseRateLimitReset(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05.000Z07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(epoch, 0), true
+	}
+	return time.Time{}, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		status int // Response status, 0 when no response arrived
+		err    error
+		want   bool
+	}{
+		{name: "rate limited GET", method: "GET", status: 429, want: true},
+		{name: "rate limited POST", method: "POST", status: 429, want: true},
+		{name: "bad gateway GET", method: "GET", status: 502, want: true},
+		{name: "unavailable PUT", method: "PUT", status: 503, want: true},
+		{name: "gateway timeout DELETE", method: "DELETE", status: 504, want: true},
+		{name: "unavailable POST", method: "POST", status: 503, want: false},
+		{name: "internal error GET", method: "GET", status: 500, want: false},
+		{name: "not found GET", method: "GET", status: 404, want: false},
+		{name: "unauthorized GET", method: "GET", status: 401, want: false},
+		{name: "network error GET", method: "GET", err: errors.New("connection reset by peer"), want: true},
+		{name: "network error POST", method: "POST", err: errors.New("connection reset by peer"), want: false},
+		{name: "cancelled GET", method: "GET", err: context.Canceled, want: false},
+		{name: "deadline exceeded GET", method: "GET", err: fmt.Errorf("request: %w", context.DeadlineExceeded), want: false},
+		{name: "unavailable before sending GET", method: "GET", err: &APIError{StatusCode: 503}, want: true},
+		{name: "unavailable before sending POST", method: "POST", err: &APIError{StatusCode: 503}, want: false},
+		{name: "login rejected", method: "GET", err: &APIError{StatusCode: 401}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.status != 0 {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			}
+			if got := shouldRetry(tt.method, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry(%s, %d, %v) = %v, want %v", tt.method, tt.status, tt.err, got, tt.want)
+			}
+		})
+	}
+}
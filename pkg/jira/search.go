@@ -0,0 +1,84 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Search returns every issue matching jql, with changelog and comments,
+// following the search API's pagination.
+func (c *Client) Search(jql string) ([]Issue, error) {
+	return c.SearchContext(context.Background(), jql)
+}
+
+func (c *Client) SearchContext(ctx context.Context, jql string) ([]Issue, error) {
+	endpoint := "/rest/api/2/search?jql=" + url.QueryEscape(jql) + "&expand=changelog,comment&fields=*all"
+
+	issues, err := paginate[Issue](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("searching %q: %w", jql, err)
+	}
+
+	return issues, nil
+}
+
+// issueLookupFields are the fields fetched by GetIssues, enough to name and
+// place an issue without its history.
+const issueLookupFields = "summary,status,issuetype"
+
+func (c *Client) GetIssues(keys []string) ([]Issue, error) {
+	return c.GetIssuesContext(context.Background(), keys)
+}
+
+// GetIssuesContext returns the issues with the given keys, without
+// changelog or comments. Issues that were deleted or cannot be viewed are
+// left out rather than failing the whole lookup, as Jira fails a key search
+// naming a single unknown key.
+func (c *Client) GetIssuesContext(ctx context.Context, keys []string) ([]Issue, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	// validateQuery=warn makes Jira skip unknown keys instead of answering 400
+	jql := fmt.Sprintf("issuekey in (%s)", strings.Join(keys, ", "))
+	endpoint := "/rest/api/2/search?jql=" + url.QueryEscape(jql) + "&validateQuery=warn&fields=" + issueLookupFields
+	issues, err := paginateAll[Issue](ctx, c, endpoint)
+	if err == nil {
+		return issues, nil
+	}
+	if !hasStatus(err, http.StatusBadRequest) || len(keys) == 1 {
+		return nil, fmt.Errorf("getting issues %s: %w", strings.Join(keys, ", "), err)
+	}
+
+	// Instances that validate anyway are asked key by key
+	issues = nil
+	for _, key := range keys {
+		issue, err := c.getIssue(ctx, key)
+		switch {
+		case err == nil:
+			issues = append(issues, *issue)
+		case !IsNotFound(err) && !hasStatus(err, http.StatusBadRequest):
+			return nil, fmt.Errorf("getting issue %s: %w", key, err)
+		}
+	}
+	return issues, nil
+}
+
+// getIssue fetches the lookup fields of a single issue.
+func (c *Client) getIssue(ctx context.Context, key string) (*Issue, error) {
+	body, err := c.makeRequest(ctx, "GET", "/rest/api/2/issue/"+url.PathEscape(key)+"?fields="+issueLookupFields, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return nil, fmt.Errorf("parsing issue %s: %w", key, err)
+	}
+
+	return &issue, nil
+}
@@ -0,0 +1,60 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate bool // The search rejects unknown keys despite validateQuery=warn
+		want     []string
+	}{
+		{name: "unknown keys skipped by the search", want: []string{"DEV-1"}},
+		{name: "unknown keys rejected by the search", validate: true, want: []string{"DEV-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/rest/api/2/search":
+					if r.URL.Query().Get("validateQuery") != "warn" || tt.validate {
+						w.WriteHeader(http.StatusBadRequest)
+						w.Write([]byte(`{"errorMessages": ["An issue with key 'DEV-9' does not exist for field 'issuekey'."]}`))
+						return
+					}
+					w.Write([]byte(`{"total": 1, "issues": [{"key": "DEV-1", "fields": {"summary": "Epic"}}]}`))
+				case r.URL.Path == "/rest/api/2/issue/DEV-1":
+					w.Write([]byte(`{"key": "DEV-1", "fields": {"summary": "Epic"}}`))
+				case strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/"):
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"errorMessages": ["Issue does not exist or you do not have permission to see it."]}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("user", "secret", server.URL)
+			client.SetRateLimit(0, 0)
+			client.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+			issues, err := client.GetIssuesContext(context.Background(), []string{"DEV-1", "DEV-9"})
+			if err != nil {
+				t.Fatalf("GetIssuesContext: %v", err)
+			}
+			var keys []string
+			for _, issue := range issues {
+				keys = append(keys, issue.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got issues %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
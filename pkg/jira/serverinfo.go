@@ -0,0 +1,56 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ServerInfo describes the Jira instance. DeploymentType is "Cloud" for
+// Jira Cloud and "Server" for Jira Server and Data Center.
+type ServerInfo struct {
+	BaseURL        string `json:"baseUrl"`
+	Version        string `json:"version"`
+	DeploymentType string `json:"deploymentType"`
+}
+
+func (c *Client) ServerInfo() (*ServerInfo, error) {
+	return c.ServerInfoContext(context.Background())
+}
+
+func (c *Client) ServerInfoContext(ctx context.Context) (*ServerInfo, error) {
+	body, err := c.makeRequest(ctx, "GET", "/rest/api/2/serverInfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting server info: %w", err)
+	}
+
+	var info ServerInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("parsing server info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// isCloud reports whether the client talks to Jira Cloud, which identifies
+// users by account ID and rejects the username parameters of Jira Server.
+// The deployment type is read from serverInfo once per client, whatever
+// domain or proxy the site is reached through. Until it can be read, sites
+// on atlassian.net are taken for Cloud.
+func (c *Client) isCloud(ctx context.Context) bool {
+	c.deploymentMu.Lock()
+	defer c.deploymentMu.Unlock()
+
+	if c.cloud == nil {
+		info, err := c.ServerInfoContext(ctx)
+		if err != nil {
+			u, err := url.Parse(c.baseURL)
+			return err == nil && strings.HasSuffix(u.Hostname(), ".atlassian.net")
+		}
+		cloud := strings.EqualFold(info.DeploymentType, "Cloud")
+		c.cloud = &cloud
+	}
+	return *c.cloud
+}
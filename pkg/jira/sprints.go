@@ -0,0 +1,176 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (c *Client) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	return c.MoveIssuesToSprintContext(context.Background(), sprintID, issueKeys)
+}
+
+// MoveIssuesToSprintContext adds issues to a sprint, taking them out of the
+// backlog or the sprint they were in.
+func (c *Client) MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprintID)
+
+	body, err := json.Marshal(map[string][]string{"issues": issueKeys})
+	if err != nil {
+		return fmt.Errorf("encoding issues: %w", err)
+	}
+
+	if _, err := c.makeRequest(ctx, "POST", endpoint, body); err != nil {
+		return fmt.Errorf("moving issues to sprint %d: %w", sprintID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) MoveIssuesToBacklog(issueKeys []string) error {
+	return c.MoveIssuesToBacklogContext(context.Background(), issueKeys)
+}
+
+// MoveIssuesToBacklogContext takes issues out of their sprint.
+func (c *Client) MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error {
+	body, err := json.Marshal(map[string][]string{"issues": issueKeys})
+	if err != nil {
+		return fmt.Errorf("encoding issues: %w", err)
+	}
+
+	if _, err := c.makeRequest(ctx, "POST", "/rest/agile/1.0/backlog/issue", body); err != nil {
+		return fmt.Errorf("moving issues to the backlog: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateSprint(boardID string, sprint Sprint) (*Sprint, error) {
+	return c.CreateSprintContext(context.Background(), boardID, sprint)
+}
+
+// CreateSprintContext adds a future sprint to a board with the name, goal
+// and dates of sprint; goal and dates may be empty.
+func (c *Client) CreateSprintContext(ctx context.Context, boardID string, sprint Sprint) (*Sprint, error) {
+	originBoardID, err := strconv.Atoi(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("creating sprint on board %s: invalid board ID", boardID)
+	}
+
+	fields := map[string]interface{}{"name": sprint.Name, "originBoardId": originBoardID}
+	for name, value := range map[string]string{"goal": sprint.Goal, "startDate": sprint.StartDate, "endDate": sprint.EndDate} {
+		if value != "" {
+			fields[name] = value
+		}
+	}
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encoding sprint: %w", err)
+	}
+
+	response, err := c.makeRequest(ctx, "POST", "/rest/agile/1.0/sprint", body)
+	if err != nil {
+		return nil, fmt.Errorf("creating sprint on board %s: %w", boardID, err)
+	}
+
+	var created Sprint
+	if err := json.Unmarshal(response, &created); err != nil {
+		return nil, fmt.Errorf("parsing sprint response: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) StartSprint(sprintID int, start, end time.Time) (*Sprint, error) {
+	return c.StartSprintContext(context.Background(), sprintID, start, end)
+}
+
+// StartSprintContext makes a future sprint the active one, running from
+// start to end.
+func (c *Client) StartSprintContext(ctx context.Context, sprintID int, start, end time.Time) (*Sprint, error) {
+	sprint, err := c.updateSprint(ctx, sprintID, map[string]interface{}{
+		"state":     "active",
+		"startDate": start.Format(sprintTime),
+		"endDate":   end.Format(sprintTime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting sprint %d: %w", sprintID, err)
+	}
+
+	return sprint, nil
+}
+
+func (c *Client) CompleteSprint(sprintID, moveTo int) ([]string, error) {
+	return c.CompleteSprintContext(context.Background(), sprintID, moveTo)
+}
+
+// CompleteSprintContext closes an active sprint. As on the board in Jira,
+// its issues that are not done are first moved on: to the sprint moveTo,
+// or to the backlog when moveTo is 0. It returns the keys of those issues,
+// also when closing the sprint fails after they were moved; the sprint can
+// then be completed again.
+func (c *Client) CompleteSprintContext(ctx context.Context, sprintID, moveTo int) ([]string, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/search?jql=%s&fields=status", url.QueryEscape(fmt.Sprintf("sprint = %d", sprintID)))
+	issues, err := paginateAll[Issue](ctx, c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("getting issues of sprint %d: %w", sprintID, err)
+	}
+
+	var incomplete []string
+	for _, issue := range issues {
+		if !issue.Fields.Status.Done() {
+			incomplete = append(incomplete, issue.Key)
+		}
+	}
+	if len(incomplete) > 0 {
+		if moveTo == 0 {
+			err = c.MoveIssuesToBacklogContext(ctx, incomplete)
+		} else {
+			err = c.MoveIssuesToSprintContext(ctx, moveTo, incomplete)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("completing sprint %d: %w", sprintID, err)
+		}
+	}
+
+	if _, err := c.updateSprint(ctx, sprintID, map[string]interface{}{"state": "closed"}); err != nil {
+		if len(incomplete) == 0 {
+			return nil, fmt.Errorf("completing sprint %d: %w", sprintID, err)
+		}
+		target := "the backlog"
+		if moveTo != 0 {
+			target = fmt.Sprintf("sprint %d", moveTo)
+		}
+		return incomplete, fmt.Errorf("closing sprint %d after its incomplete issues %s were already moved to %s: %w",
+			sprintID, strings.Join(incomplete, ", "), target, err)
+	}
+
+	return incomplete, nil
+}
+
+// sprintTime is the date layout the agile API accepts for sprints.
+const sprintTime = "2006-01-02T15:04:05.000Z07:00"
+
+// updateSprint changes the given fields of a sprint, leaving the others.
+func (c *Client) updateSprint(ctx context.Context, sprintID int, fields map[string]interface{}) (*Sprint, error) {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encoding sprint: %w", err)
+	}
+
+	response, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprintID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sprint Sprint
+	if err := json.Unmarshal(response, &sprint); err != nil {
+		return nil, fmt.Errorf("parsing sprint response: %w", err)
+	}
+
+	return &sprint, nil
+}
@@ -0,0 +1,33 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (c *Client) GetStatuses() ([]Status, error) {
+	return c.GetStatusesContext(context.Background())
+}
+
+// GetStatusesContext lists every status of the Jira instance. Board
+// configurations refer to statuses by ID only, this resolves their names.
+func (c *Client) GetStatusesContext(ctx context.Context) ([]Status, error) {
+	body, err := c.makeRequest(ctx, "GET", "/rest/api/2/status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting statuses: %w", err)
+	}
+
+	var statuses []Status
+	if err := json.Unmarshal(body, &statuses); err != nil {
+		return nil, fmt.Errorf("parsing statuses response: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// Done reports whether the status is in the "done" category, the statuses
+// that count as complete when a sprint is completed.
+func (s Status) Done() bool {
+	return s.StatusCategory != nil && s.StatusCategory.Key == "done"
+}
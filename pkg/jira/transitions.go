@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   Status `json:"to"`
+	// Fields lists the fields of the transition screen, keyed by field ID
+	Fields map[string]FieldMeta `json:"fields,omitempty"`
+}
+
+// RequiredFields returns the IDs of fields the user must fill in before the
+// transition can be performed.
+func (t Transition) RequiredFields() []string {
+	var required []string
+	for id, field := range t.Fields {
+		if field.Required && !field.HasDefaultValue {
+			required = append(required, id)
+		}
+	}
+	return required
+}
+
+type transitionsResponse struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	return c.GetTransitionsContext(context.Background(), issueKey)
+}
+
+// GetTransitionsContext lists the transitions available from the issue's
+// current status, including the fields of their screens.
+func (c *Client) GetTransitionsContext(ctx context.Context, issueKey string) ([]Transition, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions?expand=transitions.fields", issueKey)
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting transitions for %s: %w", issueKey, err)
+	}
+
+	var response transitionsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("parsing transitions response: %w", err)
+	}
+
+	return response.Transitions, nil
+}
+
+func (c *Client) DoTransition(issueKey, transitionID string, fields map[string]interface{}) error {
+	return c.DoTransitionContext(context.Background(), issueKey, transitionID, fields)
+}
+
+// DoTransitionContext performs a transition. fields carries values for the
+// transition screen, keyed by field ID, and may be nil.
+func (c *Client) DoTransitionContext(ctx context.Context, issueKey, transitionID string, fields map[string]interface{}) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueKey)
+
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	if len(fields) > 0 {
+		payload["fields"] = c.encodeUsers(ctx, fields)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding transition: %w", err)
+	}
+
+	if _, err := c.makeRequest(ctx, "POST", endpoint, body); err != nil {
+		return fmt.Errorf("transitioning %s: %w", issueKey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// User is a Jira account. Server identifies users by Name/Key, Cloud by
+// AccountID; whichever is known is set.
+type User struct {
+	Name         string `json:"name,omitempty"`
+	Key          string `json:"key,omitempty"`
+	AccountID    string `json:"accountId,omitempty"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+// Is reports whether the user is the author of a comment.
+func (u User) Is(author CommentUser) bool {
+	switch {
+	case u.AccountID != "" && author.AccountID != "":
+		return u.AccountID == author.AccountID
+	case u.Key != "" && author.Key != "":
+		return u.Key == author.Key
+	default:
+		return u.Name != "" && u.Name == author.Name
+	}
+}
+
+func (c *Client) Myself() (*User, error) {
+	return c.MyselfContext(context.Background())
+}
+
+// MyselfContext returns the user the client is authenticated as.
+func (c *Client) MyselfContext(ctx context.Context) (*User, error) {
+	body, err := c.makeRequest(ctx, "GET", "/rest/api/2/myself", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting current user: %w", err)
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("parsing user response: %w", err)
+	}
+
+	return &user, nil
+}
+
+// FieldValue is the JSON value of the user in a user field or as assignee:
+// the account ID on Cloud, the name on Server, or whichever is known when
+// the other is missing.
+func (u User) FieldValue(cloud bool) map[string]string {
+	if u.AccountID != "" && (cloud || u.Name == "") {
+		return map[string]string{"accountId": u.AccountID}
+	}
+	return map[string]string{"name": u.Name}
+}
+
+// fieldUser is a user picked for a user field, sent by the client as
+// FieldValue gives it for the instance. Encoded without a client, as by
+// fakes, it uses the account ID when known.
+type fieldUser struct {
+	User
+}
+
+func (u fieldUser) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.FieldValue(u.AccountID != ""))
+}
+
+// encodeUsers returns a copy of fields with the picked users replaced by
+// the value the instance identifies them by.
+func (c *Client) encodeUsers(ctx context.Context, fields map[string]interface{}) map[string]interface{} {
+	encode := func(value interface{}) interface{} {
+		if user, ok := value.(fieldUser); ok {
+			return user.FieldValue(c.isCloud(ctx))
+		}
+		return value
+	}
+
+	encoded := make(map[string]interface{}, len(fields))
+	for id, value := range fields {
+		if values, ok := value.([]interface{}); ok {
+			list := make([]interface{}, len(values))
+			for i, value := range values {
+				list[i] = encode(value)
+			}
+			encoded[id] = list
+			continue
+		}
+		encoded[id] = encode(value)
+	}
+	return encoded
+}
+
+func (c *Client) FindAssignableUsers(issueKey, query string) ([]User, error) {
+	return c.FindAssignableUsersContext(context.Background(), issueKey, query)
+}
+
+// FindAssignableUsersContext searches the users that may be assigned the
+// issue by name, display name or e-mail prefix. An empty query lists them
+// all, up to Jira's limit.
+func (c *Client) FindAssignableUsersContext(ctx context.Context, issueKey, query string) ([]User, error) {
+	users, err := c.findAssignableUsers(ctx, "issueKey", issueKey, query)
+	if err != nil {
+		return nil, fmt.Errorf("searching assignable users for %s: %w", issueKey, err)
+	}
+	return users, nil
+}
+
+func (c *Client) FindProjectAssignableUsers(projectKey, query string) ([]User, error) {
+	return c.FindProjectAssignableUsersContext(context.Background(), projectKey, query)
+}
+
+// FindProjectAssignableUsersContext searches the users that may be assigned
+// issues of the project, for issues that do not exist yet.
+func (c *Client) FindProjectAssignableUsersContext(ctx context.Context, projectKey, query string) ([]User, error) {
+	users, err := c.findAssignableUsers(ctx, "project", projectKey, query)
+	if err != nil {
+		return nil, fmt.Errorf("searching assignable users of %s: %w", projectKey, err)
+	}
+	return users, nil
+}
+
+// findAssignableUsers searches assignable users of the issue or project
+// given by the scope parameter.
+func (c *Client) findAssignableUsers(ctx context.Context, scope, key, query string) ([]User, error) {
+	params := url.Values{}
+	params.Set(scope, key)
+	params.Set("maxResults", "50")
+	if c.isCloud(ctx) {
+		params.Set("query", query)
+	} else {
+		params.Set("username", query)
+	}
+	endpoint := "/rest/api/2/user/assignable/search?" + params.Encode()
+
+	body, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := json.Unmarshal(body, &users); err != nil {
+		return nil, fmt.Errorf("parsing users response: %w", err)
+	}
+
+	return users, nil
+}
+
+func (c *Client) AssignIssue(issueKey string, user *User) error {
+	return c.AssignIssueContext(context.Background(), issueKey, user)
+}
+
+// AssignIssueContext assigns the issue to user, or unassigns it when user
+// is nil.
+func (c *Client) AssignIssueContext(ctx context.Context, issueKey string, user *User) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/assignee", issueKey)
+
+	payload := map[string]interface{}{}
+	switch {
+	case user != nil:
+		for key, value := range user.FieldValue(c.isCloud(ctx)) {
+			payload[key] = value
+		}
+	case c.isCloud(ctx):
+		payload["accountId"] = nil
+	default:
+		payload["name"] = nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding assignee: %w", err)
+	}
+
+	if _, err := c.makeRequest(ctx, "PUT", endpoint, body); err != nil {
+		return fmt.Errorf("assigning %s: %w", issueKey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserSearchParameter(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment string // Deployment type served, "" to fail serverInfo
+		want       string // Parameter carrying the search
+		infoCalls  int
+	}{
+		{name: "cloud on its own domain", deployment: "Cloud", want: "query", infoCalls: 1},
+		{name: "server", deployment: "Server", want: "username", infoCalls: 1},
+		{name: "server info unavailable", want: "username", infoCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			infoCalls := 0
+			var params []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/api/2/serverInfo":
+					infoCalls++
+					if tt.deployment == "" {
+						http.Error(w, "{}", http.StatusForbidden)
+						return
+					}
+					w.Write([]byte(`{"deploymentType": "` + tt.deployment + `"}`))
+				case "/rest/api/2/user/assignable/search":
+					for _, param := range []string{"query", "username"} {
+						if r.URL.Query().Get(param) == "ali" {
+							params = append(params, param)
+						}
+					}
+					w.Write([]byte(`[]`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("user", "secret", server.URL)
+			client.SetRateLimit(0, 0)
+			client.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+			for i := 0; i < 2; i++ {
+				if _, err := client.FindAssignableUsersContext(context.Background(), "DEV-1", "ali"); err != nil {
+					t.Fatalf("FindAssignableUsersContext: %v", err)
+				}
+			}
+			if len(params) != 2 || params[0] != tt.want || params[1] != tt.want {
+				t.Errorf("searched with %v, want %s", params, tt.want)
+			}
+			if infoCalls != tt.infoCalls {
+				t.Errorf("read server info %d times, want %d", infoCalls, tt.infoCalls)
+			}
+		})
+	}
+}
+
+func TestUserFieldValues(t *testing.T) {
+	user := User{Name: "alice", AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Alice"}
+	reviewers := FieldMeta{Name: "Reviewers", Schema: FieldSchema{Type: "array", Items: "user"}}
+	tester := FieldMeta{Name: "Tester", Schema: FieldSchema{Type: "user"}}
+
+	tests := []struct {
+		deployment string
+		want       string
+	}{
+		{deployment: "Cloud", want: `{"fields":{"customfield_1":[{"accountId":"5b10ac8d82e05b22cc7d4ef5"}],"customfield_2":{"accountId":"5b10ac8d82e05b22cc7d4ef5"},"summary":"Fix it"}}`},
+		{deployment: "Server", want: `{"fields":{"customfield_1":[{"name":"alice"}],"customfield_2":{"name":"alice"},"summary":"Fix it"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.deployment, func(t *testing.T) {
+			var sent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/api/2/serverInfo":
+					json.NewEncoder(w).Encode(ServerInfo{DeploymentType: tt.deployment})
+				case "/rest/api/2/issue/DEV-1":
+					body, _ := io.ReadAll(r.Body)
+					sent = string(body)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("user", "secret", server.URL)
+			client.SetRateLimit(0, 0)
+
+			fields := map[string]interface{}{
+				"summary":       "Fix it",
+				"customfield_1": reviewers.UserValue(user),
+				"customfield_2": tester.UserValue(user),
+			}
+			if err := client.UpdateIssueContext(context.Background(), "DEV-1", fields); err != nil {
+				t.Fatalf("UpdateIssueContext: %v", err)
+			}
+			if sent != tt.want {
+				t.Errorf("sent %s, want %s", sent, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ type IssueState struct {
 type BoardState struct {
 	BoardID string                 `json:"boardId"`
 	Issues  map[string]IssueState  `json:"issues"`
+	// SprintID is the sprint picked in the sprint browser, 0 shows the
+	// active sprints
+	SprintID int                   `json:"sprintId,omitempty"`
 }
 
 type AppState struct {
@@ -91,6 +94,23 @@ func (s *AppState) UpdateIssueState(boardID, issueKey, status, assignee, lastUpd
 	s.Boards[boardID] = board
 }
 
+// RemoveBoard forgets the issues and picked sprint of a board.
+func (s *AppState) RemoveBoard(boardID string) {
+	delete(s.Boards, boardID)
+}
+
+// SelectedSprint returns the sprint shown for a board, 0 for the active
+// sprints.
+func (s *AppState) SelectedSprint(boardID string) int {
+	return s.Boards[boardID].SprintID
+}
+
+func (s *AppState) SelectSprint(boardID string, sprintID int) {
+	board := s.GetBoardState(boardID)
+	board.SprintID = sprintID
+	s.Boards[boardID] = board
+}
+
 func (s *AppState) HasIssueChanged(boardID, issueKey, status, assignee string) bool {
 	board := s.GetBoardState(boardID)
 	
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// defaultSprintLength is used to start the first sprint of a board, later
+// sprints last as long as the previous one.
+const defaultSprintLength = 14 * 24 * time.Hour
+
+// openSprintMenu offers to create a sprint on the current board, start one
+// of its future sprints or complete an active one. Every change is
+// confirmed first.
+func (app *TUIApp) openSprintMenu(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	board := app.config.Boards[app.currentBoard]
+	if board.JQL != "" {
+		app.setStatusMessage("Query tabs have no sprints")
+		return nil
+	}
+
+	app.fetchSprints(board.ID, func(sprints []jira.Sprint) error {
+		sprints = sortSprints(sprints)
+		items := []string{"New sprint"}
+		actions := []func() error{func() error {
+			app.promptNewSprint(board.ID, sprints)
+			return nil
+		}}
+		for _, sprint := range sprints {
+			sprint := sprint
+			switch sprint.State {
+			case "future":
+				items = append(items, "Start "+sprint.Name)
+				actions = append(actions, func() error {
+					app.confirmStartSprint(board.ID, sprint, sprints)
+					return nil
+				})
+			case "active":
+				items = append(items, "Complete "+sprint.Name)
+				actions = append(actions, func() error {
+					app.pickIncompleteTarget(board.ID, sprint, sprints)
+					return nil
+				})
+			}
+		}
+
+		app.showPicker("Sprints of "+board.Name, items, func(index int) error {
+			return actions[index]()
+		})
+		return nil
+	})
+	return nil
+}
+
+// promptNewSprint asks for the name and goal of a new sprint, proposing the
+// name of the last sprint with its number increased.
+func (app *TUIApp) promptNewSprint(boardID string, sprints []jira.Sprint) {
+	app.showPrompt("Sprint name", nextSprintName(sprints), false, func(name string) error {
+		if name == "" {
+			return nil
+		}
+		app.showPrompt("Sprint goal (optional)", "", false, func(goal string) error {
+			app.confirm(fmt.Sprintf("Create sprint %s?", name), func() error {
+				app.setStatusMessage("Creating sprint %s...", name)
+				go func() {
+					_, err := app.jiraClient.CreateSprintContext(app.ctx, boardID, jira.Sprint{Name: name, Goal: goal})
+					if err != nil {
+						app.setStatusMessage("Cannot create sprint %s: %v", name, err)
+					} else {
+						app.setStatusMessage("Created sprint %s", name)
+					}
+					app.update(func(g *gocui.Gui) error { return nil })
+				}()
+				return nil
+			})
+			return nil
+		})
+		return nil
+	})
+}
+
+var sprintNumberPattern = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// nextSprintName numbers the next sprint after the most recently planned
+// one, "DEV Sprint 23" after "DEV Sprint 22". sprints are in picker order.
+func nextSprintName(sprints []jira.Sprint) string {
+	for _, sprint := range sprints {
+		if m := sprintNumberPattern.FindStringSubmatch(sprint.Name); m != nil {
+			number, _ := strconv.Atoi(m[2])
+			candidate := m[1] + strconv.Itoa(number+1)
+			taken := false
+			for _, other := range sprints {
+				taken = taken || other.Name == candidate
+			}
+			if !taken {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// confirmStartSprint starts a future sprint from now on, lasting as long as
+// the last sprint of the board.
+func (app *TUIApp) confirmStartSprint(boardID string, sprint jira.Sprint, sprints []jira.Sprint) {
+	start := time.Now()
+	end := start.Add(sprintLength(sprints))
+
+	title := fmt.Sprintf("Start %s, %s to %s?", sprint.Name, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	app.confirm(title, func() error {
+		app.setStatusMessage("Starting %s...", sprint.Name)
+		go func() {
+			if _, err := app.jiraClient.StartSprintContext(app.ctx, sprint.ID, start, end); err != nil {
+				app.setStatusMessage("Cannot start %s: %v", sprint.Name, err)
+				app.update(func(g *gocui.Gui) error { return nil })
+				return
+			}
+			app.setStatusMessage("Started %s", sprint.Name)
+			app.refreshBoardData(app.ctx, boardID)
+			app.update(func(g *gocui.Gui) error { return nil })
+		}()
+		return nil
+	})
+}
+
+// sprintLength returns how long the most recent sprint with dates lasted.
+// sprints are in picker order.
+func sprintLength(sprints []jira.Sprint) time.Duration {
+	for _, sprint := range sprints {
+		start, startErr := parseSprintTime(sprint.StartDate)
+		end, endErr := parseSprintTime(sprint.EndDate)
+		if sprint.State != "future" && startErr == nil && endErr == nil && end.After(start) {
+			return end.Sub(start)
+		}
+	}
+	return defaultSprintLength
+}
+
+// parseSprintTime reads sprint dates, which the agile API sends with the
+// zone either as +0000 or as +00:00.
+func parseSprintTime(value string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02T15:04:05.000Z07:00", value)
+	}
+	return t, err
+}
+
+// pickIncompleteTarget asks where the issues of an active sprint that are
+// not done go when it is completed: the backlog or a future sprint.
+func (app *TUIApp) pickIncompleteTarget(boardID string, sprint jira.Sprint, sprints []jira.Sprint) {
+	items := []string{"Backlog"}
+	targets := []jira.Sprint{{}}
+	for _, other := range sprints {
+		if other.State == "future" {
+			items = append(items, other.Name)
+			targets = append(targets, other)
+		}
+	}
+
+	app.showPicker("Move incomplete issues of "+sprint.Name+" to", items, func(index int) error {
+		target := targets[index]
+		title := fmt.Sprintf("Complete %s, moving incomplete issues to %s?", sprint.Name, items[index])
+		app.confirm(title, func() error {
+			app.completeSprint(boardID, sprint, target.ID, items[index])
+			return nil
+		})
+		return nil
+	})
+}
+
+// completeSprint closes a sprint in the background, moving its incomplete
+// issues to the sprint moveTo, or to the backlog when moveTo is 0.
+func (app *TUIApp) completeSprint(boardID string, sprint jira.Sprint, moveTo int, target string) {
+	app.setStatusMessage("Completing %s...", sprint.Name)
+
+	go func() {
+		moved, err := app.jiraClient.CompleteSprintContext(app.ctx, sprint.ID, moveTo)
+		switch {
+		case err != nil && len(moved) == 0:
+			app.setStatusMessage("Cannot complete %s: %v", sprint.Name, err)
+			app.update(func(g *gocui.Gui) error { return nil })
+			return
+		case err != nil:
+			// The issues left the sprint already, the board has to show that
+			app.setStatusMessage("Moved %d incomplete issues of %s to %s, but %s is still active, complete it again to close it: %v",
+				len(moved), sprint.Name, target, sprint.Name, err)
+		default:
+			app.setStatusMessage("Completed %s, moved %d incomplete issues to %s", sprint.Name, len(moved), target)
+		}
+		app.refreshBoardData(app.ctx, boardID)
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
+
+// openPlanIssue moves the selected card into another active or future
+// sprint of its board, or back to the backlog, once confirmed. The sprint
+// the card is in is not offered.
+func (app *TUIApp) openPlanIssue(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	app.mutex.Lock()
+	current, inSprint := app.issueSprints[boardID][issue.Key]
+	app.mutex.Unlock()
+
+	app.fetchSprints(boardID, func(sprints []jira.Sprint) error {
+		var open []jira.Sprint
+		for _, sprint := range openSprints(sprints) {
+			if !inSprint || sprint.ID != current {
+				open = append(open, sprint)
+			}
+		}
+		items := []string{"Backlog"}
+		for _, sprint := range open {
+			items = append(items, sprintLabel(sprint))
+		}
+
+		app.showPicker("Move "+issue.Key+" to", items, func(index int) error {
+			sprintID, target := 0, "the backlog"
+			if index > 0 {
+				sprintID, target = open[index-1].ID, open[index-1].Name
+			}
+			app.confirm(fmt.Sprintf("Move %s to %s?", issue.Key, target), func() error {
+				app.planIssue(boardID, issue.Key, sprintID, target)
+				return nil
+			})
+			return nil
+		})
+		return nil
+	})
+	return nil
+}
+
+// planIssue moves an issue to a sprint, or to the backlog when sprintID is
+// 0, and refreshes the board.
+func (app *TUIApp) planIssue(boardID, key string, sprintID int, target string) {
+	app.setStatusMessage("Moving %s to %s...", key, target)
+
+	go func() {
+		var err error
+		if sprintID == 0 {
+			err = app.jiraClient.MoveIssuesToBacklogContext(app.ctx, []string{key})
+		} else {
+			err = app.jiraClient.MoveIssuesToSprintContext(app.ctx, sprintID, []string{key})
+		}
+		if err != nil {
+			app.setStatusMessage("Moving %s to %s failed: %v", key, target, err)
+		} else {
+			app.setStatusMessage("Moved %s to %s", key, target)
+			app.refreshBoardData(app.ctx, boardID)
+		}
+		app.update(func(g *gocui.Gui) error { return nil })
+	}()
+}
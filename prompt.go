@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+const promptView = "prompt"
+
+// promptState describes the single-line input shown on top of the board.
+type promptState struct {
+	title    string
+	initial  string
+	mask     bool
+	onSubmit func(value string) error
+	returnTo string // View focused before the prompt was opened
+}
+
+// showPrompt opens a modal input. It must run on the gocui main loop, use
+// app.update when calling from a background goroutine.
+func (app *TUIApp) showPrompt(title, initial string, mask bool, onSubmit func(value string) error) {
+	app.gui.DeleteView(promptView)
+	app.prompt = &promptState{
+		title:    title,
+		initial:  initial,
+		mask:     mask,
+		onSubmit: onSubmit,
+		returnTo: app.currentViewName(promptView),
+	}
+}
+
+func (app *TUIApp) layoutPrompt(g *gocui.Gui, maxX, maxY int) error {
+	if app.prompt == nil {
+		return nil
+	}
+
+	width := maxX / 2
+	if width < 30 {
+		width = maxX - 2
+	}
+	x0, y0, x1, y1 := centered(maxX, maxY, width, 2)
+
+	v, err := g.SetView(promptView, x0, y0, x1, y1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = app.prompt.title + " (Enter: ok, Esc: cancel)"
+		v.Editable = true
+		if app.prompt.mask {
+			v.Mask = '*'
+		}
+		fmt.Fprint(v, app.prompt.initial)
+		v.SetCursor(len([]rune(app.prompt.initial)), 0)
+	}
+
+	g.SetViewOnTop(promptView)
+	g.SetCurrentView(promptView)
+	return nil
+}
+
+func (app *TUIApp) closePrompt(g *gocui.Gui) {
+	returnTo := ""
+	if app.prompt != nil {
+		returnTo = app.prompt.returnTo
+	}
+	app.prompt = nil
+	g.DeleteView(promptView)
+	restoreFocus(g, returnTo)
+}
+
+func (app *TUIApp) submitPrompt(g *gocui.Gui, v *gocui.View) error {
+	if app.prompt == nil {
+		return nil
+	}
+	value := strings.TrimSpace(v.Buffer())
+	onSubmit := app.prompt.onSubmit
+
+	// Close first so the callback can open a follow-up prompt
+	app.closePrompt(g)
+	return onSubmit(value)
+}
+
+func (app *TUIApp) cancelPrompt(g *gocui.Gui, v *gocui.View) error {
+	app.closePrompt(g)
+	return nil
+}
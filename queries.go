@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+
+	"jira-boards-tui/pkg/config"
+
+	"github.com/jroimartin/gocui"
+)
+
+// openQueryPrompt asks for JQL and shows the result as a tab.
+func (app *TUIApp) openQueryPrompt(g *gocui.Gui, v *gocui.View) error {
+	initial := ""
+	if app.currentBoard < len(app.config.Boards) {
+		initial = app.config.Boards[app.currentBoard].JQL
+	}
+
+	app.showPrompt("JQL", initial, false, func(jql string) error {
+		if jql == "" {
+			return nil
+		}
+		return app.openQuery(jql)
+	})
+	return nil
+}
+
+// openQuery switches to the tab of a query, adding an unsaved tab named
+// after the JQL when there is none yet.
+func (app *TUIApp) openQuery(jql string) error {
+	app.mutex.Lock()
+	index := -1
+	for i, board := range app.config.Boards {
+		if board.JQL == jql {
+			index = i
+		}
+	}
+	if index < 0 {
+		app.config.Boards = append(app.config.Boards, config.QueryBoard(jql, jql))
+		index = len(app.config.Boards) - 1
+	}
+	app.mutex.Unlock()
+
+	if index >= 9 {
+		app.setStatusMessage("Tab %d has no number key, press ] / [ or T to reach it again", index+1)
+	}
+	return app.switchBoard(index)
+}
+
+// closeQuery removes the current query tab and shows the tab before it. A
+// saved query stays in the config file and is back on the next start.
+func (app *TUIApp) closeQuery(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) || app.config.Boards[app.currentBoard].JQL == "" {
+		app.setStatusMessage("Only query tabs can be closed")
+		return nil
+	}
+	index := app.currentBoard
+	board := app.config.Boards[index]
+
+	app.mutex.Lock()
+	// A new array, copies handed out by boardList keep the old one
+	app.config.Boards = append(app.config.Boards[:index:index], app.config.Boards[index+1:]...)
+	delete(app.boardData, board.ID)
+	delete(app.boardSprints, board.ID)
+	delete(app.issueSprints, board.ID)
+	delete(app.cardFilters, board.ID)
+	// Saved queries come back on the next start and keep their history
+	if board.Name == board.JQL {
+		app.appState.RemoveBoard(board.ID)
+		app.appState.SaveState(app.stateFile)
+	}
+	app.mutex.Unlock()
+
+	if board.Name == board.JQL {
+		app.setStatusMessage("Closed query %s", board.JQL)
+	} else {
+		app.setStatusMessage("Closed query %s, it stays saved in the config file", board.Name)
+	}
+	if index > 0 {
+		index--
+	}
+	return app.switchBoard(index)
+}
+
+// forgetClosedQueries drops the state of query tabs that are not open, as
+// of ad-hoc queries run before, so the state file does not keep every query
+// ever run.
+func (app *TUIApp) forgetClosedQueries() {
+	open := make(map[string]bool)
+	for _, board := range app.config.Boards {
+		open[board.ID] = true
+	}
+	for boardID := range app.appState.Boards {
+		if config.IsQueryID(boardID) && !open[boardID] {
+			app.appState.RemoveBoard(boardID)
+		}
+	}
+}
+
+// nextTab and previousTab step through the boards, query tabs and the
+// summary, wrapping around, so tabs past the number keys can be reached.
+func (app *TUIApp) nextTab(g *gocui.Gui, v *gocui.View) error {
+	return app.stepTab(1)
+}
+
+func (app *TUIApp) previousTab(g *gocui.Gui, v *gocui.View) error {
+	return app.stepTab(-1)
+}
+
+func (app *TUIApp) stepTab(delta int) error {
+	count := len(app.config.Boards) + 1
+	return app.switchBoard(((app.currentBoard+delta)%count + count) % count)
+}
+
+// openTabPicker lists every tab, the summary last, and switches to the
+// chosen one.
+func (app *TUIApp) openTabPicker(g *gocui.Gui, v *gocui.View) error {
+	items := make([]string, 0, len(app.config.Boards)+1)
+	for i, board := range app.config.Boards {
+		kind := "Board"
+		if board.JQL != "" {
+			kind = "Query"
+		}
+		items = append(items, fmt.Sprintf("%2d  %s: %s", i+1, kind, board.Name))
+	}
+	items = append(items, fmt.Sprintf("%2d  Summary", len(app.config.Boards)+1))
+
+	app.showPicker("Tabs", items, func(index int) error {
+		return app.switchBoard(index)
+	})
+	return nil
+}
+
+// saveQuery names the query of the current tab and stores it in the config
+// file, so it comes back as a tab on the next start.
+func (app *TUIApp) saveQuery(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) || app.config.Boards[app.currentBoard].JQL == "" {
+		app.setStatusMessage("Only query tabs can be saved, press / to run a query")
+		return nil
+	}
+	index := app.currentBoard
+	board := app.config.Boards[index]
+
+	initial := board.Name
+	if initial == board.JQL {
+		initial = ""
+	}
+	app.showPrompt("Save query as", initial, false, func(name string) error {
+		if name == "" {
+			return nil
+		}
+
+		if app.configPath != "" {
+			if err := config.SaveQuery(app.configPath, config.Query{Name: name, JQL: board.JQL}); err != nil {
+				app.setStatusMessage("Cannot save query: %v", err)
+				return nil
+			}
+		}
+
+		app.mutex.Lock()
+		app.config.Boards[index].Name = name
+		app.mutex.Unlock()
+
+		if app.configPath == "" {
+			app.setStatusMessage("Renamed query to %s, there is no config file to save it to", name)
+		} else {
+			app.setStatusMessage("Saved query %s to %s", name, app.configPath)
+		}
+		return nil
+	})
+	return nil
+}
+
+// boardConfig returns the configured board or query tab with the given ID.
+func (app *TUIApp) boardConfig(boardID string) (config.Board, bool) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	for _, board := range app.config.Boards {
+		if board.ID == boardID {
+			return board, true
+		}
+	}
+	return config.Board{}, false
+}
+
+// hasBoard reports whether a board or query tab with the given ID is open.
+// The caller must hold app.mutex.
+func (app *TUIApp) hasBoard(boardID string) bool {
+	for _, board := range app.config.Boards {
+		if board.ID == boardID {
+			return true
+		}
+	}
+	return false
+}
+
+// boardList returns a copy of the tabs for use outside the main loop, where
+// a query tab may be added meanwhile.
+func (app *TUIApp) boardList() []config.Board {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	return append([]config.Board(nil), app.config.Boards...)
+}
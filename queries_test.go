@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira/jiratest"
+)
+
+func TestForgetClosedQueries(t *testing.T) {
+	saved := config.QueryBoard("Mine", "assignee = currentUser()")
+	cfg := config.Default()
+	cfg.Boards = []config.Board{{ID: "1", Name: "Board"}, saved}
+	app := newTestApp(t, cfg, jiratest.NewStore(jiratest.Fixtures{}))
+
+	adHoc := config.QueryBoard("status = Open", "status = Open").ID
+	for _, boardID := range []string{"1", "2", saved.ID, adHoc} {
+		app.appState.UpdateIssueState(boardID, "DEV-1", "Open", "Unassigned", "")
+	}
+
+	app.forgetClosedQueries()
+
+	for boardID, want := range map[string]bool{"1": true, "2": true, saved.ID: true, adHoc: false} {
+		if _, ok := app.appState.Boards[boardID]; ok != want {
+			t.Errorf("state of %q kept: %v, want %v", boardID, ok, want)
+		}
+	}
+}
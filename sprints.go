@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// openSprintPicker lists the sprints of the current board and shows the
+// chosen one instead of the active sprints, read-only unless it is active.
+// The choice is kept in the state file.
+func (app *TUIApp) openSprintPicker(g *gocui.Gui, v *gocui.View) error {
+	if app.currentBoard >= len(app.config.Boards) {
+		return nil
+	}
+	board := app.config.Boards[app.currentBoard]
+	if board.JQL != "" {
+		app.setStatusMessage("Query tabs have no sprints")
+		return nil
+	}
+
+	app.fetchSprints(board.ID, func(sprints []jira.Sprint) error {
+		app.showSprintPicker(board.ID, sortSprints(sprints))
+		return nil
+	})
+	return nil
+}
+
+// fetchSprints loads all sprints of a board in the background and hands
+// them to then on the main loop.
+func (app *TUIApp) fetchSprints(boardID string, then func([]jira.Sprint) error) {
+	app.setStatusMessage("Loading sprints...")
+
+	go func() {
+		details, err := app.getBoardDetails(app.ctx, boardID)
+		if err == nil && details.Type == "kanban" {
+			err = fmt.Errorf("kanban boards have no sprints")
+		}
+		var sprints []jira.Sprint
+		if err == nil {
+			sprints, err = app.jiraClient.GetBoardSprintsContext(app.ctx, boardID)
+		}
+
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot load the sprints of board %s: %v", boardID, err)
+				return nil
+			}
+			app.setStatusMessage("")
+			return then(sprints)
+		})
+	}()
+}
+
+func (app *TUIApp) showSprintPicker(boardID string, sprints []jira.Sprint) {
+	items := []string{"Active sprints (live)"}
+	for _, sprint := range sprints {
+		items = append(items, sprintLabel(sprint))
+	}
+
+	app.showPicker("Sprints", items, func(index int) error {
+		sprintID := 0
+		if index > 0 {
+			sprintID = sprints[index-1].ID
+		}
+		app.selectSprint(boardID, sprintID)
+		return nil
+	})
+}
+
+// sortSprints orders sprints for the picker: future ones in the order they
+// are planned, then the active ones, then closed ones from the most recent.
+func sortSprints(sprints []jira.Sprint) []jira.Sprint {
+	rank := map[string]int{"future": 0, "active": 1, "closed": 2}
+	sorted := append([]jira.Sprint(nil), sprints...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if rank[sorted[i].State] != rank[sorted[j].State] {
+			return rank[sorted[i].State] < rank[sorted[j].State]
+		}
+		if sorted[i].State == "closed" {
+			return sorted[i].StartDate > sorted[j].StartDate
+		}
+		return false
+	})
+	return sorted
+}
+
+// openSprints returns the sprints issues can still be planned into, the
+// future and active ones, in picker order.
+func openSprints(sprints []jira.Sprint) []jira.Sprint {
+	var open []jira.Sprint
+	for _, sprint := range sortSprints(sprints) {
+		if sprint.State != "closed" {
+			open = append(open, sprint)
+		}
+	}
+	return open
+}
+
+// sprintLabel describes a sprint in one line: state, name, dates and goal.
+func sprintLabel(sprint jira.Sprint) string {
+	dates := "no dates"
+	if sprint.StartDate != "" {
+		dates = fmt.Sprintf("%.10s - %.10s", sprint.StartDate, sprint.EndDate)
+	}
+	label := fmt.Sprintf("%-7s %s  %s", sprint.State, sprint.Name, dates)
+	if sprint.Goal != "" {
+		goal := []rune(sprint.Goal)
+		if len(goal) > 50 {
+			goal = append(goal[:50], '…')
+		}
+		label += "  " + string(goal)
+	}
+	return label
+}
+
+// selectSprint switches the board to a sprint, 0 going back to the active
+// sprints, and loads it right away.
+func (app *TUIApp) selectSprint(boardID string, sprintID int) {
+	app.mutex.Lock()
+	app.appState.SelectSprint(boardID, sprintID)
+	app.appState.SaveState(app.stateFile)
+	// Keep the previous sprint's cards off the board until the new one loads
+	delete(app.boardData, boardID)
+	delete(app.boardSprints, boardID)
+	app.mutex.Unlock()
+
+	go app.refreshBoardData(app.ctx, boardID)
+}
+
+// fetchBoardSprints returns the sprints to show for a board: the one
+// picked in the sprint browser, or else the active sprints. It also returns
+// the ID of the picked sprint, 0 when showing the active ones. A picked
+// sprint that no longer exists is forgotten.
+func (app *TUIApp) fetchBoardSprints(ctx context.Context, boardID string) ([]jira.Sprint, int, error) {
+	app.mutex.Lock()
+	sprintID := app.appState.SelectedSprint(boardID)
+	app.mutex.Unlock()
+
+	if sprintID != 0 {
+		sprint, err := app.jiraClient.GetSprintDetailsContext(ctx, sprintID)
+		if err == nil {
+			return []jira.Sprint{*sprint}, sprintID, nil
+		}
+		if !jira.IsNotFound(err) {
+			return nil, sprintID, err
+		}
+
+		app.mutex.Lock()
+		app.appState.SelectSprint(boardID, 0)
+		app.statusMessage = fmt.Sprintf("Sprint %d no longer exists, showing the active sprints", sprintID)
+		app.mutex.Unlock()
+	}
+
+	sprints, err := app.jiraClient.GetActiveSprintsContext(ctx, boardID)
+	return sprints, 0, err
+}
+
+// viewedSprint returns the sprint picked for a board, nil when the board
+// shows its active sprints or the sprint is not loaded yet. The caller must
+// hold app.mutex.
+func (app *TUIApp) viewedSprint(boardID string) *jira.Sprint {
+	sprintID := app.appState.SelectedSprint(boardID)
+	if sprintID == 0 {
+		return nil
+	}
+	for i, sprint := range app.boardSprints[boardID] {
+		if sprint.ID == sprintID {
+			return &app.boardSprints[boardID][i]
+		}
+	}
+	return nil
+}
+
+// isReadOnly reports whether a board shows a sprint other than an active
+// one, whose issues are not changed from the TUI. The caller must hold
+// app.mutex.
+func (app *TUIApp) isReadOnly(boardID string) bool {
+	if app.appState.SelectedSprint(boardID) == 0 {
+		return false
+	}
+	sprint := app.viewedSprint(boardID)
+	return sprint == nil || sprint.State != "active"
+}
+
+// writable guards an issue action, refusing it while the current board
+// shows a closed or future sprint.
+func (app *TUIApp) writable(action func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if app.currentBoard < len(app.config.Boards) {
+			app.mutex.Lock()
+			readOnly := app.isReadOnly(app.config.Boards[app.currentBoard].ID)
+			app.mutex.Unlock()
+			if readOnly {
+				app.setStatusMessage("This sprint is read-only, press s to go back to the active sprints")
+				return nil
+			}
+		}
+		return action(g, v)
+	}
+}
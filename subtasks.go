@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// boardCards returns the issues that get a card of their own, filtered and
+// ordered by custom fields as chosen. With sub-tasks hidden, sub-tasks
+// whose parent is on the board are left out; the card of the parent shows
+// their progress. The caller must hold app.mutex.
+func (app *TUIApp) boardCards(boardID string, issues []jira.Issue) []jira.Issue {
+	if !app.hideSubtasks {
+		return app.filterCards(boardID, issues)
+	}
+
+	onBoard := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		onBoard[issue.Key] = true
+	}
+	cards := make([]jira.Issue, 0, len(issues))
+	for _, issue := range issues {
+		parent := issue.Fields.Parent
+		if issue.Fields.IssueType.IsSubtask() && parent != nil && onBoard[parent.Key] {
+			continue
+		}
+		cards = append(cards, issue)
+	}
+	return app.filterCards(boardID, cards)
+}
+
+// parentKey returns the key of the parent of an issue, "" for none.
+func parentKey(issue jira.Issue) string {
+	if issue.Fields.Parent == nil {
+		return ""
+	}
+	return issue.Fields.Parent.Key
+}
+
+// withoutSubtasks leaves the sub-tasks out of issues.
+func withoutSubtasks(issues []jira.Issue) []jira.Issue {
+	result := make([]jira.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.Fields.IssueType.IsSubtask() {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// subtaskSummary is the child progress shown on the card of an issue with
+// sub-tasks, "" for other issues.
+func subtaskSummary(issue jira.Issue) string {
+	done, total := issue.Fields.SubtaskProgress()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[%d/%d done]", done, total)
+}
+
+// toggleSubtasks rolls sub-tasks up into the cards of their parents, or
+// shows them as cards again.
+func (app *TUIApp) toggleSubtasks(g *gocui.Gui, v *gocui.View) error {
+	app.mutex.Lock()
+	app.hideSubtasks = !app.hideSubtasks
+	hidden := app.hideSubtasks
+	app.mutex.Unlock()
+
+	if hidden {
+		app.setStatusMessage("Sub-tasks rolled up into their parents")
+	} else {
+		app.setStatusMessage("Sub-tasks shown as cards")
+	}
+	return nil
+}
+
+// renderHierarchy draws the issue between its parent and its sub-tasks,
+// each with its status. Issues without either have no tree.
+func renderHierarchy(v *gocui.View, issue *jira.Issue, width int) {
+	f := issue.Fields
+	if f.Parent == nil && len(f.Subtasks) == 0 {
+		return
+	}
+
+	title := "Hierarchy"
+	if done, total := f.SubtaskProgress(); total > 0 {
+		title = fmt.Sprintf("Hierarchy (%d/%d sub-tasks done)", done, total)
+	}
+	detailSection(v, title, width)
+
+	indent := ""
+	if f.Parent != nil {
+		fmt.Fprintln(v, refLine(f.Parent.Key, f.Parent.Fields.Summary, f.Parent.Fields.Status))
+		indent = "└─ "
+	}
+	fmt.Fprintf(v, "%s\033[1m%s\033[0m\n", indent, refLine(issue.Key, f.Summary, f.Status))
+
+	if f.Parent != nil {
+		indent = "   "
+	}
+	for i, subtask := range f.Subtasks {
+		branch := "├─ "
+		if i == len(f.Subtasks)-1 {
+			branch = "└─ "
+		}
+		fmt.Fprintln(v, indent+branch+refLine(subtask.Key, subtask.Fields.Summary, subtask.Fields.Status))
+	}
+}
+
+func refLine(key, summary string, status jira.Status) string {
+	return fmt.Sprintf("%s  %s  [%s]", key, summary, status.Name)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"jira-boards-tui/pkg/jira"
+
+	"github.com/jroimartin/gocui"
+)
+
+// selectedIssue returns the card under the cursor of a status column or the
+// backlog, or the issue of the detail view, along with the board it belongs
+// to.
+func (app *TUIApp) selectedIssue(v *gocui.View) (string, jira.Issue, bool) {
+	if v == nil || app.currentBoard >= len(app.config.Boards) {
+		return "", jira.Issue{}, false
+	}
+	if v.Name() == detailView && app.detail != nil {
+		return app.detailIssue()
+	}
+
+	_, oy := v.Origin()
+	_, cy := v.Cursor()
+	index := oy + cy
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	keys := app.viewIssues[v.Name()]
+	if index < 0 || index >= len(keys) {
+		return "", jira.Issue{}, false
+	}
+
+	boardID := app.config.Boards[app.currentBoard].ID
+	if v.Name() == backlogView {
+		issue, ok := app.backlogIssue(boardID, keys[index])
+		return boardID, issue, ok
+	}
+	for _, issue := range app.boardData[boardID] {
+		if issue.Key == keys[index] {
+			return boardID, issue, true
+		}
+	}
+	return "", jira.Issue{}, false
+}
+
+// setStatusMessage replaces the message shown in the header. The caller
+// must not hold app.mutex.
+func (app *TUIApp) setStatusMessage(format string, args ...interface{}) {
+	app.mutex.Lock()
+	app.statusMessage = fmt.Sprintf(format, args...)
+	app.mutex.Unlock()
+}
+
+// fetchTransitions loads the transitions of an issue in the background and
+// hands them to then on the main loop.
+func (app *TUIApp) fetchTransitions(issue jira.Issue, then func([]jira.Transition) error) {
+	app.setStatusMessage("Loading transitions for %s...", issue.Key)
+
+	go func() {
+		transitions, err := app.jiraClient.GetTransitionsContext(app.ctx, issue.Key)
+		app.update(func(g *gocui.Gui) error {
+			if err != nil {
+				app.setStatusMessage("Cannot load transitions for %s: %v", issue.Key, err)
+				return nil
+			}
+			if len(transitions) == 0 {
+				app.setStatusMessage("No transitions available for %s", issue.Key)
+				return nil
+			}
+			app.setStatusMessage("")
+			return then(transitions)
+		})
+	}()
+}
+
+// openTransitionPicker lists the transitions available for the selected
+// issue and performs the chosen one.
+func (app *TUIApp) openTransitionPicker(g *gocui.Gui, v *gocui.View) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	app.fetchTransitions(issue, func(transitions []jira.Transition) error {
+		items := make([]string, len(transitions))
+		for i, t := range transitions {
+			items[i] = t.Name
+			if t.Name != t.To.Name {
+				items[i] = fmt.Sprintf("%s -> %s", t.Name, t.To.Name)
+			}
+		}
+		app.showPicker("Transition "+issue.Key, items, func(index int) error {
+			app.startTransition(boardID, issue, transitions[index])
+			return nil
+		})
+		return nil
+	})
+	return nil
+}
+
+func (app *TUIApp) moveIssueLeft(g *gocui.Gui, v *gocui.View) error {
+	return app.moveIssue(v, -1)
+}
+
+func (app *TUIApp) moveIssueRight(g *gocui.Gui, v *gocui.View) error {
+	return app.moveIssue(v, 1)
+}
+
+// moveIssue transitions the selected issue into the adjacent column, using
+// the first transition whose target status maps to that column.
+func (app *TUIApp) moveIssue(v *gocui.View, direction int) error {
+	boardID, issue, ok := app.selectedIssue(v)
+	if !ok {
+		return nil
+	}
+
+	app.mutex.Lock()
+	columns := app.columnOrder(boardID, app.boardData[boardID], issue)
+	current := app.mapStatusToGroup(boardID, issue.Fields.Status)
+	app.mutex.Unlock()
+
+	target := ""
+	for i, column := range columns {
+		if column == current && i+direction >= 0 && i+direction < len(columns) {
+			target = columns[i+direction]
+		}
+	}
+	if target == "" {
+		app.setStatusMessage("No column to move %s to", issue.Key)
+		return nil
+	}
+
+	app.fetchTransitions(issue, func(transitions []jira.Transition) error {
+		app.mutex.Lock()
+		var move *jira.Transition
+		for i := range transitions {
+			if move == nil && app.mapStatusToGroup(boardID, transitions[i].To) == target {
+				move = &transitions[i]
+			}
+		}
+		app.mutex.Unlock()
+
+		if move != nil {
+			app.startTransition(boardID, issue, *move)
+			return nil
+		}
+		app.setStatusMessage("No transition moves %s to %s", issue.Key, target)
+		return nil
+	})
+	return nil
+}
+
+// columnOrder returns the columns an issue can be moved between: the
+// board's workflow, or the visible columns when those are auto-detected or
+// the issue's status is not part of the workflow. The caller must hold
+// app.mutex.
+func (app *TUIApp) columnOrder(boardID string, issues []jira.Issue, issue jira.Issue) []string {
+	workflow := app.workflowFor(boardID)
+	if !workflow.AutoDetect {
+		current := app.mapStatusToGroup(boardID, issue.Fields.Status)
+		for _, column := range workflow.Columns {
+			if column == current {
+				return workflow.Columns
+			}
+		}
+	}
+	return app.getAllStatuses(boardID, issues)
+}
+
+// startTransition performs t right away, or first asks for the fields its
+// screen requires.
+func (app *TUIApp) startTransition(boardID string, issue jira.Issue, t jira.Transition) {
+	required := t.RequiredFields()
+	if len(required) == 0 {
+		app.performTransition(boardID, issue, t, nil, nil)
+		return
+	}
+	sort.Strings(required)
+
+	fields := make([]*formField, 0, len(required))
+	for _, id := range required {
+		fields = append(fields, metaFormField(id, t.Fields[id], ""))
+	}
+	searchUsers(fields, t.Fields, func(query string) ([]jira.User, error) {
+		return app.jiraClient.FindAssignableUsersContext(app.ctx, issue.Key, query)
+	})
+
+	title := fmt.Sprintf("%s: %s", issue.Key, t.Name)
+	app.showForm(title, fields, func(values map[string]string) {
+		payload, errors := app.form.fieldValues(t.Fields, values, false)
+		if !app.setFormErrors(errors) {
+			return
+		}
+
+		app.form.submitting = true
+		app.performTransition(boardID, issue, t, payload, app.finishForm)
+	})
+}
+
+// performTransition moves the card right away and sends the transition in
+// the background. On failure the card moves back; either way the next
+// refresh reconciles the board with Jira. done, if set, receives the result.
+func (app *TUIApp) performTransition(boardID string, issue jira.Issue, t jira.Transition, fields map[string]interface{}, done func(err error, onSuccess func())) {
+	previous := issue.Fields.Status
+	app.updateCachedIssue(boardID, issue.Key, func(cached *jira.Issue) {
+		cached.Fields.Status = t.To
+	})
+	app.setStatusMessage("Moving %s to %s...", issue.Key, t.To.Name)
+
+	go func() {
+		err := app.jiraClient.DoTransitionContext(app.ctx, issue.Key, t.ID, fields)
+
+		if err != nil {
+			app.setStatusMessage("Moving %s failed: %v", issue.Key, err)
+			app.updateCachedIssue(boardID, issue.Key, func(cached *jira.Issue) {
+				cached.Fields.Status = previous
+			})
+		} else {
+			app.setStatusMessage("Moved %s to %s", issue.Key, t.To.Name)
+			app.rememberOwnChange(boardID, issue.Key)
+		}
+		if done != nil {
+			done(err, nil)
+		} else {
+			app.update(func(g *gocui.Gui) error { return nil })
+		}
+	}()
+}
+
+// updateCachedIssue changes an issue in the board cache so the change shows
+// before the next refresh brings Jira's version.
+func (app *TUIApp) updateCachedIssue(boardID, key string, update func(issue *jira.Issue)) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	for i := range app.boardData[boardID] {
+		if app.boardData[boardID][i].Key == key {
+			update(&app.boardData[boardID][i])
+		}
+	}
+}
+
+// rememberOwnChange stores the cached state of an issue as seen, so a change
+// made from the TUI is not highlighted as news on the next refresh.
+func (app *TUIApp) rememberOwnChange(boardID, key string) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	for _, issue := range app.boardData[boardID] {
+		if issue.Key != key {
+			continue
+		}
+		assignee := "Unassigned"
+		if issue.Fields.Assignee != nil {
+			assignee = issue.Fields.Assignee.Name
+		}
+		app.appState.UpdateIssueState(boardID, key, issue.Fields.Status.Name, assignee, issue.Fields.Updated)
+	}
+}
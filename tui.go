@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"jira-boards-tui/pkg/config"
 	"jira-boards-tui/pkg/jira"
@@ -25,7 +26,7 @@ type ChangeNotification struct {
 type TUIApp struct {
 	gui               *gocui.Gui
 	config            *config.Config
-	jiraClient        *jira.Client
+	jiraClient        jira.API
 	currentBoard      int
 	boardData         map[string][]jira.Issue
 	mutex             sync.Mutex
@@ -37,18 +38,89 @@ type TUIApp struct {
 	stateFile         string
 	autoSwitchEnabled bool
 	boardSwitchTime   time.Time // Time when user last switched to current board
+	ctx               context.Context    // Cancelled on quit to abort all in-flight requests
+	cancel            context.CancelFunc
+	switchCancel      context.CancelFunc // Cancels the refresh started by the last board switch
+	creds             credentials // Resolved credentials, reused when re-prompting after 401
+	configPath        string      // Config file queries are saved to, empty in demo mode
+	boardErrors       map[string]error // Last refresh error per board ID
+	missingBoards     map[string]bool  // Boards Jira reported as deleted, skipped on refresh
+	authFailed        bool             // Jira rejected the credentials, refreshes wait for new ones
+	backoffUntil      time.Time        // Refreshes are paused until then after rate limiting
+	statusMessage     string           // Shown in the header
+	prompt            *promptState     // Open modal input, nil when none
+	picker            *pickerState     // Open modal list, nil when none
+	form              *formState       // Open modal form, nil when none
+	composer          *composerState   // Open comment editor, nil when none
+	detail            *detailState     // Open issue detail view, nil when none
+	dependencies      *dependencyState // Open dependency view, nil when none
+	myself            *jira.User       // Authenticated user, fetched on first use
+	boardSprints      map[string][]jira.Sprint // Sprints shown per board ID, from the last refresh
+	issueSprints      map[string]map[string]int // Sprint ID per issue key per board ID, from the last refresh
+	boardDetails      map[string]*jira.Board   // Board type and project per board ID, fetched on first use
+	boardConfigurations map[string]*jira.BoardConfiguration // Board filter setup per board ID, fetched on first use
+	boardWorkflows    map[string]*config.Workflow // Columns derived from the board configuration per board ID
+	backlogs          map[string]*backlogState    // Open backlog tabs per board ID
+	statuses          []jira.Status // Statuses of the Jira instance, fetched on first use
+	fields            []jira.Field  // Fields of the Jira instance, fetched on first use
+	epics             map[string]*jira.Epic // Epics by key, for naming epics issues only link to
+	swimlanes         bool // Columns are split into a lane per epic
+	epicProgress      bool // The epic progress replaces the activity panel
+	hideSubtasks      bool // Sub-tasks are rolled up into the cards of their parents
+	cardFilters       map[string]*cardFilter // Custom field filter of the cards per board ID
+	cardSort          string // Custom field cards are ordered by, "-" prefixed for descending
+	createMeta        map[string][]jira.IssueTypeMeta // Create screens per project key
+	viewIssues        map[string][]string // Issue keys per status view, in display order
 }
 
-func NewTUIApp(configPath string, username, password string) (*TUIApp, error) {
+func NewTUIApp(configPath string, creds credentials) (*TUIApp, error) {
 	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("loading config: %w", err)
 	}
 
-	client := jira.NewClient(username, password, cfg.JiraURL)
+	// Flag wins over config; a lone token implies bearer auth
+	if creds.authType == "" {
+		creds.authType = cfg.Auth.Type
+	}
+	if creds.authType == "" && creds.username == "" && creds.token != "" {
+		creds.authType = "bearer"
+	}
+	if creds.authType == "" {
+		creds.authType = "basic"
+	}
+	
+	auth, err := creds.authenticator(cfg.JiraURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := jira.NewClientWithAuth(cfg.JiraURL, auth)
+	configureClient(client, cfg)
 	
+	app, err := initTUIApp(cfg, client, creds, "jira-summary-state.json")
+	if err != nil {
+		return nil, err
+	}
+	app.configPath = configPath
+	return app, nil
+}
+
+// configureClient applies the paging, retry and rate limit settings.
+func configureClient(client *jira.Client, cfg *config.Config) {
+	client.SetPageSize(cfg.PageSize)
+	client.SetMaxResults(cfg.MaxResults)
+	client.SetRetryPolicy(jira.RetryPolicy{
+		MaxAttempts:    cfg.Retry.MaxAttempts,
+		InitialBackoff: time.Duration(cfg.Retry.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(cfg.Retry.MaxBackoffMs) * time.Millisecond,
+	})
+	client.SetRateLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+}
+
+// initTUIApp loads the persisted state and sets up the terminal UI.
+func initTUIApp(cfg *config.Config, client jira.API, creds credentials, stateFile string) (*TUIApp, error) {
 	// Load application state
-	stateFile := "jira-summary-state.json"
 	appState, err := state.LoadState(stateFile)
 	if err != nil {
 		// Create default state if loading fails
@@ -58,21 +130,13 @@ func NewTUIApp(configPath string, username, password string) (*TUIApp, error) {
 		}
 	}
 	
-	app := &TUIApp{
-		config:            cfg,
-		jiraClient:        client,
-		boardData:         make(map[string][]jira.Issue),
-		changes:           make([]string, 0),
-		changeQueue:       make([]ChangeNotification, 0),
-		lastUpdate:        time.Now(),
-		appState:          appState,
-		stateFile:         stateFile,
-		autoSwitchEnabled: true,
-		boardSwitchTime:   time.Now(),
-	}
+	app := newTUIApp(cfg, client, appState, stateFile)
+	app.creds = creds
+	app.forgetClosedQueries()
 
 	g, err := gocui.NewGui(gocui.OutputNormal)
 	if err != nil {
+		app.cancel()
 		return nil, err
 	}
 	app.gui = g
@@ -83,15 +147,52 @@ func NewTUIApp(configPath string, username, password string) (*TUIApp, error) {
 	g.SelBgColor = gocui.ColorDefault
 	g.BgColor = gocui.ColorDefault
 	g.FgColor = gocui.ColorWhite
+	g.InputEsc = true
 	g.SetManagerFunc(app.layout)
 
 	if err := app.setupKeyBindings(); err != nil {
+		app.cancel()
 		return nil, err
 	}
 
 	return app, nil
 }
 
+// newTUIApp builds the application state without a terminal, so board
+// logic can run against any jira.API, e.g. the fakes in package jiratest.
+func newTUIApp(cfg *config.Config, client jira.API, appState *state.AppState, stateFile string) *TUIApp {
+	ctx, cancel := context.WithCancel(context.Background())
+	
+	return &TUIApp{
+		config:            cfg,
+		jiraClient:        client,
+		boardData:         make(map[string][]jira.Issue),
+		changes:           make([]string, 0),
+		changeQueue:       make([]ChangeNotification, 0),
+		lastUpdate:        time.Now(),
+		appState:          appState,
+		stateFile:         stateFile,
+		autoSwitchEnabled: true,
+		boardSwitchTime:   time.Now(),
+		ctx:               ctx,
+		cancel:            cancel,
+		boardErrors:       make(map[string]error),
+		missingBoards:     make(map[string]bool),
+		boardSprints:      make(map[string][]jira.Sprint),
+		issueSprints:      make(map[string]map[string]int),
+		boardDetails:      make(map[string]*jira.Board),
+		boardConfigurations: make(map[string]*jira.BoardConfiguration),
+		boardWorkflows:    make(map[string]*config.Workflow),
+		backlogs:          make(map[string]*backlogState),
+		epics:             make(map[string]*jira.Epic),
+		swimlanes:         cfg.Swimlanes == "epic",
+		hideSubtasks:      cfg.HideSubtasks,
+		cardFilters:       make(map[string]*cardFilter),
+		cardSort:          cfg.CardSort,
+		createMeta:        make(map[string][]jira.IssueTypeMeta),
+	}
+}
+
 func (app *TUIApp) setupKeyBindings() error {
 	g := app.gui
 
@@ -107,19 +208,27 @@ func (app *TUIApp) setupKeyBindings() error {
 
 	// Board switching (1-9 keys) - fix closure issue
 	// Add +1 for summary view
-	maxBoards := len(app.config.Boards) + 1
-	for i := 0; i < 9 && i < maxBoards; i++ {
+	// Bound for all digits, query tabs can be added while running
+	for i := 0; i < 9; i++ {
 		key := rune('1' + i)
 		// Create closure to capture correct index
 		func(boardIndex int) {
 			g.SetKeybinding("", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+				// Global bindings fire before the editor - keep digits typeable in prompts
+				if v != nil && v.Editable && v.Editor != nil {
+					v.Editor.Edit(v, 0, key, gocui.ModNone)
+					return nil
+				}
+				if app.modalOpen() {
+					return nil
+				}
 				return app.switchBoard(boardIndex)
 			})
 		}(i)
 	}
 
 	// Vim-style navigation keys for all views
-	views := []string{"ready_for_test", "in_testing", "summary", "changelog", "global_summary"}
+	views := []string{"ready_for_test", "in_testing", "summary", "changelog", "global_summary", backlogView, epicsView}
 	for i := 0; i < 10; i++ {
 		views = append(views, fmt.Sprintf("status_%d", i))
 	}
@@ -142,11 +251,155 @@ func (app *TUIApp) setupKeyBindings() error {
 		g.SetKeybinding(viewName, 'g', gocui.ModNone, app.goToTop)
 		g.SetKeybinding(viewName, 'G', gocui.ModNone, app.goToBottom)
 	}
+
+	// Issue actions on the status columns
+	for i := 0; i < 10; i++ {
+		viewName := fmt.Sprintf("status_%d", i)
+		g.SetKeybinding(viewName, 't', gocui.ModNone, app.writable(app.openTransitionPicker))
+		g.SetKeybinding(viewName, '<', gocui.ModNone, app.writable(app.moveIssueLeft))
+		g.SetKeybinding(viewName, '>', gocui.ModNone, app.writable(app.moveIssueRight))
+		g.SetKeybinding(viewName, 'c', gocui.ModNone, app.writable(app.composeComment))
+		g.SetKeybinding(viewName, 'C', gocui.ModNone, app.writable(app.manageComments))
+		g.SetKeybinding(viewName, 'a', gocui.ModNone, app.writable(app.openAssignPicker))
+		g.SetKeybinding(viewName, 'm', gocui.ModNone, app.writable(app.assignToMe))
+		g.SetKeybinding(viewName, 'u', gocui.ModNone, app.writable(app.unassign))
+		g.SetKeybinding(viewName, 'e', gocui.ModNone, app.writable(app.editIssue))
+		g.SetKeybinding(viewName, 'p', gocui.ModNone, app.writable(app.openPlanIssue))
+		g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, app.openDetail)
+	}
+
+	// Backlog: details and planning into a sprint
+	g.SetKeybinding(backlogView, gocui.KeyEnter, gocui.ModNone, app.openDetail)
+	g.SetKeybinding(backlogView, '>', gocui.ModNone, app.openMoveToSprint)
+
+	// Issue detail: scrolling and the same actions as on the card
+	detailKeys := map[interface{}]func(*gocui.Gui, *gocui.View) error{
+		'j': app.detailDown, gocui.KeyArrowDown: app.detailDown,
+		'k': app.detailUp, gocui.KeyArrowUp: app.detailUp,
+		gocui.KeyCtrlF: app.detailPageDown, gocui.KeyCtrlB: app.detailPageUp,
+		'g': app.detailTop, 'G': app.detailBottom,
+		gocui.KeyEsc: app.closeDetail, 'q': app.closeDetail,
+		't': app.openTransitionPicker, '<': app.moveIssueLeft, '>': app.moveIssueRight,
+		'c': app.composeComment, 'C': app.manageComments,
+		'a': app.openAssignPicker, 'm': app.assignToMe, 'u': app.unassign,
+		'e': app.editIssue,
+	}
+	for key, handler := range detailKeys {
+		switch key {
+		case 't', '<', '>', 'c', 'C', 'a', 'm', 'u', 'e':
+			detailKeys[key] = app.writable(handler)
+		}
+	}
+	for key, handler := range detailKeys {
+		if err := g.SetKeybinding(detailView, key, gocui.ModNone, handler); err != nil {
+			return err
+		}
+	}
+
+	// Dependency view: scrolling only
+	dependencyKeys := map[interface{}]func(*gocui.Gui, *gocui.View) error{
+		'j': app.detailDown, gocui.KeyArrowDown: app.detailDown,
+		'k': app.detailUp, gocui.KeyArrowUp: app.detailUp,
+		gocui.KeyCtrlF: app.detailPageDown, gocui.KeyCtrlB: app.detailPageUp,
+		'g': app.detailTop, 'G': app.detailBottom,
+		gocui.KeyEsc: app.closeDependencies, 'q': app.closeDependencies, 'D': app.closeDependencies,
+	}
+	for key, handler := range dependencyKeys {
+		if err := g.SetKeybinding(dependenciesView, key, gocui.ModNone, handler); err != nil {
+			return err
+		}
+	}
+
+	// Board commands, from anywhere on a board
+	boardViews := []string{"header", "loading", "changelog", backlogView, epicsView}
+	for i := 0; i < 10; i++ {
+		boardViews = append(boardViews, fmt.Sprintf("status_%d", i))
+	}
+	for _, viewName := range boardViews {
+		g.SetKeybinding(viewName, 'n', gocui.ModNone, app.writable(app.openCreateIssue))
+		g.SetKeybinding(viewName, 's', gocui.ModNone, app.openSprintPicker)
+		g.SetKeybinding(viewName, 'S', gocui.ModNone, app.openSprintMenu)
+		g.SetKeybinding(viewName, 'b', gocui.ModNone, app.toggleBacklog)
+		g.SetKeybinding(viewName, 'L', gocui.ModNone, app.toggleSwimlanes)
+		g.SetKeybinding(viewName, 'E', gocui.ModNone, app.toggleEpicProgress)
+		g.SetKeybinding(viewName, 'H', gocui.ModNone, app.toggleSubtasks)
+		g.SetKeybinding(viewName, 'D', gocui.ModNone, app.openDependencies)
+		g.SetKeybinding(viewName, 'F', gocui.ModNone, app.openCardFilter)
+		g.SetKeybinding(viewName, 'O', gocui.ModNone, app.openCardSort)
+		g.SetKeybinding(viewName, '/', gocui.ModNone, app.openQueryPrompt)
+		g.SetKeybinding(viewName, 'w', gocui.ModNone, app.saveQuery)
+		g.SetKeybinding(viewName, 'x', gocui.ModNone, app.closeQuery)
+	}
+	g.SetKeybinding("global_summary", '/', gocui.ModNone, app.openQueryPrompt)
+	
+	// Tabs past the number keys
+	for _, viewName := range append(boardViews, "global_summary") {
+		g.SetKeybinding(viewName, ']', gocui.ModNone, app.nextTab)
+		g.SetKeybinding(viewName, '[', gocui.ModNone, app.previousTab)
+		g.SetKeybinding(viewName, 'T', gocui.ModNone, app.openTabPicker)
+	}
 	
 	// Tab navigation
 	if err := g.SetKeybinding("", gocui.KeyTab, gocui.ModNone, app.moveToNextView); err != nil {
 		return err
 	}
+	
+	// Modal prompt
+	if err := g.SetKeybinding(promptView, gocui.KeyEnter, gocui.ModNone, app.submitPrompt); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(promptView, gocui.KeyEsc, gocui.ModNone, app.cancelPrompt); err != nil {
+		return err
+	}
+
+	// Modal picker; j/k are typed into searchable pickers
+	if err := g.SetKeybinding(pickerView, 'j', gocui.ModNone, app.pickerKey('j', app.pickerDown)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(pickerView, 'k', gocui.ModNone, app.pickerKey('k', app.pickerUp)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(pickerView, gocui.KeyArrowDown, gocui.ModNone, app.pickerDown); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(pickerView, gocui.KeyArrowUp, gocui.ModNone, app.pickerUp); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(pickerView, gocui.KeyEnter, gocui.ModNone, app.selectPicker); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(pickerView, gocui.KeyEsc, gocui.ModNone, app.cancelPicker); err != nil {
+		return err
+	}
+
+	// Comment composer
+	if err := g.SetKeybinding(composerView, gocui.KeyCtrlS, gocui.ModNone, app.submitComposer); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(composerView, gocui.KeyEsc, gocui.ModNone, app.cancelComposer); err != nil {
+		return err
+	}
+
+	// Modal form
+	for _, key := range []interface{}{'j', gocui.KeyArrowDown} {
+		if err := g.SetKeybinding(formView, key, gocui.ModNone, app.formDown); err != nil {
+			return err
+		}
+	}
+	for _, key := range []interface{}{'k', gocui.KeyArrowUp} {
+		if err := g.SetKeybinding(formView, key, gocui.ModNone, app.formUp); err != nil {
+			return err
+		}
+	}
+	if err := g.SetKeybinding(formView, gocui.KeyEnter, gocui.ModNone, app.editFormField); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(formView, gocui.KeyCtrlS, gocui.ModNone, app.submitForm); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(formView, gocui.KeyEsc, gocui.ModNone, app.cancelForm); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -170,25 +423,19 @@ func (app *TUIApp) layout(g *gocui.Gui) error {
 		app.updateHeader(headerView)
 	}
 
-	// Clear all views properly
-	viewsToDelete := []string{"loading", "summary", "changelog", "global_summary"}
-	for i := 0; i < 10; i++ {
-		viewsToDelete = append(viewsToDelete, fmt.Sprintf("status_%d", i))
-	}
-	
-	for _, viewName := range viewsToDelete {
-		if v, err := g.View(viewName); err == nil && v != nil {
-			g.DeleteView(viewName)
-		}
-	}
+	// Views are kept between redraws so cursors and focus survive; the ones
+	// not laid out in this pass are deleted at the end
+	keep := map[string]bool{"header": true}
 	
 	// Reset active views list
 	app.activeViews = []string{}
+	app.viewIssues = make(map[string][]string)
 
 	// Main content area - show current board stats or global summary
 	if app.currentBoard == len(app.config.Boards) {
 		// Global summary view
-		if v, err := g.SetView("global_summary", 0, 3, maxX-1, maxY-1); err != nil {
+		v, err := g.SetView("global_summary", 0, 3, maxX-1, maxY-1)
+		if err != nil {
 			if err != gocui.ErrUnknownView {
 				return err
 			}
@@ -196,21 +443,32 @@ func (app *TUIApp) layout(g *gocui.Gui) error {
 			v.Wrap = false
 			v.BgColor = gocui.ColorDefault
 			v.FgColor = gocui.ColorWhite
-			app.updateGlobalSummaryView(v)
 		}
+		app.updateGlobalSummaryView(v)
 		app.activeViews = append(app.activeViews, "global_summary")
 	} else if app.currentBoard < len(app.config.Boards) {
 		boardID := app.config.Boards[app.currentBoard].ID
 		issues := app.boardData[boardID]
 
-		// Get all statuses except "Closed"
-		statuses := app.getAllStatuses(issues)
+		// Get the board's columns, without hidden statuses
+		statuses := app.getAllStatuses(boardID, issues)
 		statusCount := len(statuses)
 		
-		if statusCount > 0 {
+		if backlog := app.backlogs[boardID]; backlog != nil {
+			// The backlog tab takes the place of the columns
+			leftWidth := (maxX * 2) / 3
+			if err := app.layoutBacklog(g, app.config.Boards[app.currentBoard].Name, backlog, 0, 3, leftWidth-1, maxY-1); err != nil {
+				return err
+			}
+		} else if statusCount > 0 {
 			// Use left 2/3 of screen for status columns, right 1/3 for activity
 			leftWidth := (maxX * 2) / 3
 			colWidth := leftWidth / statusCount
+			cards := app.boardCards(boardID, issues)
+			var lanes []epicGroup
+			if app.swimlanes {
+				lanes = app.epicGroups(boardID, cards)
+			}
 			
 			for i, status := range statuses {
 				x1 := i * colWidth
@@ -220,68 +478,171 @@ func (app *TUIApp) layout(g *gocui.Gui) error {
 				}
 				
 				viewName := fmt.Sprintf("status_%d", i)
-				if v, err := g.SetView(viewName, x1, 3, x2, maxY-1); err != nil {
+				v, err := g.SetView(viewName, x1, 3, x2, maxY-1)
+				if err != nil {
 					if err != gocui.ErrUnknownView {
 						return err
 					}
-					v.Title = status
 					v.Highlight = true
 					v.SelBgColor = gocui.ColorDefault
 					v.SelFgColor = gocui.ColorWhite
 					v.BgColor = gocui.ColorDefault
 					v.FgColor = gocui.ColorWhite
-					app.updateTaskView(v, issues, status)
+				}
+				v.Title = app.columnTitle(boardID, status, issues)
+				if len(lanes) > 0 {
+					app.updateLaneView(v, boardID, lanes, status)
+				} else {
+					app.updateTaskView(v, boardID, cards, status)
 				}
 				app.activeViews = append(app.activeViews, viewName)
 			}
 		} else {
 			// No issues loaded yet - use left 2/3 of screen
 			leftWidth := (maxX * 2) / 3
-			if v, err := g.SetView("loading", 0, 3, leftWidth-1, maxY-1); err != nil {
+			v, err := g.SetView("loading", 0, 3, leftWidth-1, maxY-1)
+			if err != nil {
 				if err != gocui.ErrUnknownView {
 					return err
 				}
-				v.Title = "Loading..."
+				v.Wrap = true
 				v.BgColor = gocui.ColorDefault
 				v.FgColor = gocui.ColorWhite
+			}
+			v.Clear()
+			if app.missingBoards[boardID] {
+				v.Title = "Board not found"
+				fmt.Fprintln(v, "Jira reports that this board no longer exists, it is skipped until Ctrl+R.")
+			} else if boardErr := app.boardErrors[boardID]; boardErr != nil {
+				v.Title = "Error"
+				fmt.Fprintf(v, "Failed to load issues:\n%v\n", boardErr)
+			} else if _, loaded := app.boardData[boardID]; loaded && app.config.Boards[app.currentBoard].JQL != "" {
+				v.Title = "No issues"
+				fmt.Fprintln(v, "No issues match the query.")
+			} else {
+				v.Title = "Loading..."
 				fmt.Fprintln(v, "Loading issues...")
 			}
+			keep["loading"] = true
 		}
 
 		// Sprint changelog view - now takes right 1/3 of screen
 		leftWidth := (maxX * 2) / 3
-		if v, err := g.SetView("changelog", leftWidth, 3, maxX-1, maxY-1); err != nil {
-			if err != gocui.ErrUnknownView {
-				return err
+		if app.epicProgress {
+			// The epic progress takes the place of the activity
+			v, err := g.SetView(epicsView, leftWidth, 3, maxX-1, maxY-1)
+			if err != nil {
+				if err != gocui.ErrUnknownView {
+					return err
+				}
+				v.Wrap = false
+				v.BgColor = gocui.ColorDefault
+				v.FgColor = gocui.ColorWhite
+			}
+			if sprints := app.boardSprints[boardID]; len(sprints) == 1 {
+				v.Title = fmt.Sprintf("Epic Progress - %s", sprints[0].Name)
+			} else {
+				v.Title = fmt.Sprintf("Epic Progress - Board: %s", app.config.Boards[app.currentBoard].Name)
+			}
+			app.updateEpicProgressView(v, boardID, issues)
+			app.activeViews = append(app.activeViews, epicsView)
+		} else {
+			v, err := g.SetView("changelog", leftWidth, 3, maxX-1, maxY-1)
+			if err != nil {
+				if err != gocui.ErrUnknownView {
+					return err
+				}
+				v.Wrap = false
+				v.BgColor = gocui.ColorDefault
+				v.FgColor = gocui.ColorWhite
+				v.Autoscroll = true
+			}
+			if board := app.config.Boards[app.currentBoard]; board.JQL != "" {
+				v.Title = fmt.Sprintf("Activity - Query: %s", board.Name)
+			} else if details := app.boardDetails[board.ID]; details != nil && details.Type == "kanban" {
+				v.Title = fmt.Sprintf("Activity - Board: %s", board.Name)
+			} else if sprint := app.viewedSprint(board.ID); sprint != nil {
+				v.Title = fmt.Sprintf("Sprint Activity - %s (%s)", sprint.Name, sprint.State)
+			} else {
+				v.Title = fmt.Sprintf("Sprint Activity - Board: %s", board.Name)
 			}
-			v.Title = fmt.Sprintf("Sprint Activity - Board: %s", app.config.Boards[app.currentBoard].Name)
-			v.Wrap = false
-			v.BgColor = gocui.ColorDefault
-			v.FgColor = gocui.ColorWhite
-			v.Autoscroll = true
 			app.updateSprintChangelog(v, issues)
+			app.activeViews = append(app.activeViews, "changelog")
 		}
-		app.activeViews = append(app.activeViews, "changelog")
 	}
 
 	// Remove Changes view - all changes are now shown in activity
 
+	for _, name := range app.activeViews {
+		keep[name] = true
+	}
+	if err := app.layoutOverlays(g, maxX, maxY, keep); err != nil {
+		return err
+	}
+	
+	var stale []string
+	for _, v := range g.Views() {
+		if !keep[v.Name()] {
+			stale = append(stale, v.Name())
+		}
+	}
+	for _, name := range stale {
+		g.DeleteView(name)
+	}
+	
+	app.ensureFocus(g)
+	
 	return nil
 }
 
-func (app *TUIApp) getAllStatuses(issues []jira.Issue) []string {
+// ensureFocus moves focus to the first board view when the focused view was
+// deleted, and marks the selected card only in the focused column.
+func (app *TUIApp) ensureFocus(g *gocui.Gui) {
+	current := g.CurrentView()
+	if current != nil {
+		if live, err := g.View(current.Name()); err != nil || live != current || current.Name() == "header" {
+			current = nil
+		}
+	}
+	// Wait for the columns of a loading board rather than parking focus on
+	// the activity panel
+	if current == nil && len(app.activeViews) > 0 && app.activeViews[0] != "changelog" && app.activeViews[0] != epicsView {
+		current, _ = g.SetCurrentView(app.activeViews[0])
+	}
+	
+	for _, name := range app.activeViews {
+		if v, err := g.View(name); err == nil && v.Highlight {
+			if v == current {
+				v.SelBgColor = gocui.ColorBlue
+			} else {
+				v.SelBgColor = gocui.ColorDefault
+			}
+		}
+	}
+}
+
+// getAllStatuses returns the columns of a board in display order. The
+// caller must hold app.mutex.
+func (app *TUIApp) getAllStatuses(boardID string, issues []jira.Issue) []string {
+	workflow := app.workflowFor(boardID)
+	
 	// Auto-detect workflow if enabled
-	if app.config.Workflow.AutoDetect {
-		return app.autoDetectWorkflow(issues)
+	if workflow.AutoDetect {
+		return app.autoDetectWorkflow(workflow, issues)
+	}
+	
+	// Boards show their columns even when empty, as in Jira
+	if workflow.FromBoard {
+		return workflow.Columns
 	}
 	
 	// Use configured columns
-	wantedStatuses := app.config.Workflow.Columns
+	wantedStatuses := workflow.Columns
 	
 	// Check which statuses actually exist in the data (with mapping)
 	statusExists := make(map[string]bool)
 	for _, issue := range issues {
-		mappedStatus := app.mapStatusToGroup(issue.Fields.Status.Name)
+		mappedStatus := app.mapStatusToGroup(boardID, issue.Fields.Status)
 		statusExists[mappedStatus] = true
 	}
 	
@@ -296,30 +657,128 @@ func (app *TUIApp) getAllStatuses(issues []jira.Issue) []string {
 	return existingStatuses
 }
 
-func (app *TUIApp) mapStatusToGroup(status string) string {
-	// Use configured status mapping
-	for _, mapping := range app.config.Workflow.StatusMapping {
+// mapStatusToGroup returns the column of a status on the given board, or ""
+// for hidden statuses and, on boards taking their columns from Jira, for
+// statuses no column maps. The caller must hold app.mutex.
+func (app *TUIApp) mapStatusToGroup(boardID string, status jira.Status) string {
+	workflow := app.workflowFor(boardID)
+	if hiddenStatus(workflow, status) {
+		return ""
+	}
+	
+	// Use configured status mapping, by ID where known
+	for _, mapping := range workflow.StatusMapping {
+		for _, id := range mapping.StatusIDs {
+			if status.ID != "" && status.ID == id {
+				return mapping.Column
+			}
+		}
 		for _, mappedStatus := range mapping.Statuses {
-			if status == mappedStatus {
+			if config.MatchStatus(mappedStatus, status.Name) {
 				return mapping.Column
 			}
 		}
 	}
 	
-	// Special case for Closed - always filter out
-	if status == "Closed" {
-		return "Closed"
+	// Boards leave statuses out of their columns to hide them, as Jira does
+	if workflow.FromBoard {
+		return ""
+	}
+	
+	// Place unknown statuses by their category
+	if column := categoryColumn(workflow, status); column != "" {
+		return column
 	}
 	
 	// Keep original status if no mapping found
-	return status
+	return status.Name
 }
 
-func (app *TUIApp) autoDetectWorkflow(issues []jira.Issue) []string {
+// hiddenStatus reports whether issues in status are left off the board.
+func hiddenStatus(workflow config.Workflow, status jira.Status) bool {
+	for _, hidden := range workflow.HiddenStatuses {
+		if config.MatchStatus(hidden, status.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryColumn returns the column for a status no mapping matches, by its
+// Jira status category: the column configured for the category, the column
+// named like it, or else the first column for To Do, the second for In
+// Progress and the last for Done. It returns "" when there is none.
+func categoryColumn(workflow config.Workflow, status jira.Status) string {
+	category := status.StatusCategory
+	columns := workflow.Columns
+	if category == nil || len(columns) == 0 {
+		return ""
+	}
+	
+	for name, column := range workflow.CategoryColumns {
+		if strings.EqualFold(name, category.Name) || strings.EqualFold(name, category.Key) {
+			return column
+		}
+	}
+	for _, column := range columns {
+		if strings.EqualFold(column, category.Name) {
+			return column
+		}
+	}
+	
+	switch category.Key {
+	case "new":
+		return columns[0]
+	case "indeterminate":
+		if len(columns) > 2 {
+			return columns[1]
+		}
+	case "done":
+		return columns[len(columns)-1]
+	}
+	return ""
+}
+
+// columnTitle names a status column, followed by its issue count and limits
+// when it has any. A leading "!" marks a column outside its limits.
+func (app *TUIApp) columnTitle(boardID, column string, issues []jira.Issue) string {
+	limit, ok := app.workflowFor(boardID).Limits[column]
+	if !ok || limit.Min == 0 && limit.Max == 0 {
+		return column
+	}
+	
+	count := 0
+	for _, issue := range issues {
+		if limit.ExcludeSubtasks && issue.Fields.IssueType != nil && issue.Fields.IssueType.Subtask {
+			continue
+		}
+		if app.mapStatusToGroup(boardID, issue.Fields.Status) == column {
+			count++
+		}
+	}
+	
+	var bounds string
+	switch {
+	case limit.Min > 0 && limit.Max > 0:
+		bounds = fmt.Sprintf("%d-%d", limit.Min, limit.Max)
+	case limit.Max > 0:
+		bounds = fmt.Sprintf("max %d", limit.Max)
+	default:
+		bounds = fmt.Sprintf("min %d", limit.Min)
+	}
+	
+	title := fmt.Sprintf("%s %d/%s", column, count, bounds)
+	if count < limit.Min || limit.Max > 0 && count > limit.Max {
+		title = "! " + title
+	}
+	return title
+}
+
+func (app *TUIApp) autoDetectWorkflow(workflow config.Workflow, issues []jira.Issue) []string {
 	// Collect all unique statuses from issues
 	statusCount := make(map[string]int)
 	for _, issue := range issues {
-		if issue.Fields.Status.Name != "Closed" {
+		if !hiddenStatus(workflow, issue.Fields.Status) {
 			statusCount[issue.Fields.Status.Name]++
 		}
 	}
@@ -352,16 +811,48 @@ func (app *TUIApp) updateHeader(v *gocui.View) {
 	v.Clear()
 	if app.currentBoard == len(app.config.Boards) {
 		// Summary view
-		fmt.Fprintf(v, "Summary View | Press 1-%d to switch | Ctrl+R refresh | Last: %s",
-			len(app.config.Boards)+1, app.lastUpdate.Format("15:04:05"))
+		fmt.Fprintf(v, "Summary View | %s | Ctrl+R refresh | Last: %s",
+			app.switchHint(), app.lastUpdate.Format("15:04:05"))
 	} else if app.currentBoard >= 0 && app.currentBoard < len(app.config.Boards) {
 		board := app.config.Boards[app.currentBoard]
-		fmt.Fprintf(v, "Board: %s (%s) | Press 1-%d to switch | Ctrl+R refresh | Last: %s",
-			board.Name, board.ID, len(app.config.Boards)+1, app.lastUpdate.Format("15:04:05"))
+		if board.JQL != "" {
+			fmt.Fprintf(v, "Query: %s", board.Name)
+			if board.Name != board.JQL {
+				fmt.Fprintf(v, " (%s)", board.JQL)
+			}
+		} else {
+			fmt.Fprintf(v, "Board: %s (%s)", board.Name, board.ID)
+			if sprint := app.viewedSprint(board.ID); sprint != nil {
+				fmt.Fprintf(v, " | Sprint: %s", sprint.Name)
+				if app.isReadOnly(board.ID) {
+					fmt.Fprintf(v, " (%s, read-only)", sprint.State)
+				}
+			}
+		}
+		if filter := app.cardFilters[board.ID]; filter != nil {
+			fmt.Fprintf(v, " | Filter: %s", filter.text)
+		}
+		if app.cardSort != "" {
+			fmt.Fprintf(v, " | Sort: %s", app.cardSort)
+		}
+		fmt.Fprintf(v, " | %s | Ctrl+R refresh | Last: %s",
+			app.switchHint(), app.lastUpdate.Format("15:04:05"))
+	}
+	if app.statusMessage != "" {
+		fmt.Fprintf(v, " | %s", app.statusMessage)
+	}
+}
+
+// switchHint names the keys that switch tabs, the number keys reaching the
+// first nine only.
+func (app *TUIApp) switchHint() string {
+	if tabs := len(app.config.Boards) + 1; tabs <= 9 {
+		return fmt.Sprintf("Press 1-%d to switch", tabs)
 	}
+	return "Press 1-9, ] / [ or T to switch"
 }
 
-func (app *TUIApp) updateTaskView(v *gocui.View, issues []jira.Issue, status string) {
+func (app *TUIApp) updateTaskView(v *gocui.View, boardID string, issues []jira.Issue, status string) {
 	v.Clear()
 	
 	// Debug output
@@ -372,41 +863,11 @@ func (app *TUIApp) updateTaskView(v *gocui.View, issues []jira.Issue, status str
 	
 	found := false
 	for _, issue := range issues {
-		mappedStatus := app.mapStatusToGroup(issue.Fields.Status.Name)
+		mappedStatus := app.mapStatusToGroup(boardID, issue.Fields.Status)
 		if mappedStatus == status {
 			found = true
-			priority := ""
-			if issue.Fields.Priority != nil {
-				priority = issue.Fields.Priority.Name
-			}
-			assignee := "Unassigned"
-			if issue.Fields.Assignee != nil {
-				assignee = issue.Fields.Assignee.Name
-			}
-			
-			summary := issue.Fields.Summary
-			if len(summary) > 20 {
-				summary = summary[:20] + "..."
-			}
-			
-			// Check if this issue has recent changes (should be highlighted in red)
-			isNewChange := app.isIssueNewChange(issue.Key)
-			
-			// Show original status in parentheses for clarity
-			originalStatus := issue.Fields.Status.Name
-			line := ""
-			if originalStatus != status {
-				line = fmt.Sprintf("%s | %s | %s | %s (%s)", issue.Key, priority, assignee, summary, originalStatus)
-			} else {
-				line = fmt.Sprintf("%s | %s | %s | %s", issue.Key, priority, assignee, summary)
-			}
-			
-			// Add red highlighting for new changes
-			if isNewChange {
-				line = "\033[31m" + line + "\033[0m" // Red ANSI color
-			}
-			
-			fmt.Fprintln(v, line)
+			fmt.Fprintln(v, app.cardLine(issue, status, true))
+			app.viewIssues[v.Name()] = append(app.viewIssues[v.Name()], issue.Key)
 		}
 	}
 	
@@ -415,6 +876,59 @@ func (app *TUIApp) updateTaskView(v *gocui.View, issues []jira.Issue, status str
 	}
 }
 
+// cardLine renders the card of an issue in a column, with the name of its
+// epic unless the card sits in the epic's swimlane. The caller must hold
+// app.mutex.
+func (app *TUIApp) cardLine(issue jira.Issue, status string, withEpic bool) string {
+	priority := ""
+	if issue.Fields.Priority != nil {
+		priority = issue.Fields.Priority.Name
+	}
+	assignee := "Unassigned"
+	if issue.Fields.Assignee != nil {
+		assignee = issue.Fields.Assignee.Name
+	}
+	
+	summary := issue.Fields.Summary
+	if len(summary) > 20 {
+		summary = summary[:20] + "..."
+	}
+	if progress := subtaskSummary(issue); progress != "" {
+		summary += " " + progress
+	}
+	if epic := app.issueEpic(issue); withEpic && epic != nil {
+		name := []rune(epicName(epic))
+		if len(name) > 16 {
+			name = name[:16]
+		}
+		summary += " [" + string(name) + "]"
+	}
+	if blocked := blockedSummary(issue); blocked != "" {
+		summary += " [" + blocked + "]"
+	}
+	for _, value := range app.customValues(issue, true) {
+		summary += " [" + value + "]"
+	}
+	
+	// Check if this issue has recent changes (should be highlighted in red)
+	isNewChange := app.isIssueNewChange(issue.Key)
+	
+	// Show original status in parentheses for clarity
+	originalStatus := issue.Fields.Status.Name
+	line := ""
+	if originalStatus != status {
+		line = fmt.Sprintf("%s | %s | %s | %s (%s)", issue.Key, priority, assignee, summary, originalStatus)
+	} else {
+		line = fmt.Sprintf("%s | %s | %s | %s", issue.Key, priority, assignee, summary)
+	}
+	
+	// Add red highlighting for new changes
+	if isNewChange {
+		line = "\033[31m" + line + "\033[0m" // Red ANSI color
+	}
+	return line
+}
+
 func (app *TUIApp) isIssueNewChange(issueKey string) bool {
 	// Get current board ID
 	currentBoardID := ""
@@ -433,96 +947,125 @@ func (app *TUIApp) isIssueNewChange(issueKey string) bool {
 	return false
 }
 
-func (app *TUIApp) updateSummaryView(v *gocui.View, issues []jira.Issue) {
-	v.Clear()
-	
-	if len(issues) == 0 {
-		fmt.Fprintln(v, "No issues to display")
-		return
+// assigneeStats counts issues per assignee and column. Boards may have
+// different workflows, so columns are listed in the order they are first
+// seen. Custom fields configured with sum are added up per assignee.
+type assigneeStats struct {
+	columns  []string
+	counts   map[string]map[string]int
+	sumNames []string
+	sums     map[string]map[string]float64
+}
+
+// addBoardStats counts the visible issues of a board, with the board's columns.
+// The caller must hold app.mutex.
+func (app *TUIApp) addBoardStats(stats *assigneeStats, boardID string, issues []jira.Issue) {
+	if stats.counts == nil {
+		stats.counts = make(map[string]map[string]int)
+		stats.sums = make(map[string]map[string]float64)
+		for _, field := range app.config.CustomFields {
+			if field.Sum {
+				stats.sumNames = append(stats.sumNames, field.Name)
+			}
+		}
+	}
+	addColumn := func(column string) {
+		for _, existing := range stats.columns {
+			if existing == column {
+				return
+			}
+		}
+		stats.columns = append(stats.columns, column)
+	}
+	for _, column := range app.getAllStatuses(boardID, issues) {
+		addColumn(column)
 	}
-	
-	stats := make(map[string]map[string]int)
 	
 	for _, issue := range issues {
+		mappedStatus := app.mapStatusToGroup(boardID, issue.Fields.Status)
+		// Hidden statuses are not counted
+		if mappedStatus == "" {
+			continue
+		}
+		addColumn(mappedStatus)
+		
 		assignee := "Unassigned"
 		if issue.Fields.Assignee != nil {
 			assignee = issue.Fields.Assignee.Name
 		}
-		
-		if stats[assignee] == nil {
-			stats[assignee] = make(map[string]int)
+		if stats.counts[assignee] == nil {
+			stats.counts[assignee] = make(map[string]int)
 		}
+		stats.counts[assignee][mappedStatus]++
+		stats.counts[assignee]["Total"]++
 		
-		mappedStatus := app.mapStatusToGroup(issue.Fields.Status.Name)
-		// Include all statuses except Closed
-		if mappedStatus != "Closed" {
-			stats[assignee][mappedStatus]++
-			stats[assignee]["Total"]++
+		for _, field := range app.config.CustomFields {
+			value, ok := issue.Fields.Number(app.customFieldID(field))
+			if !field.Sum || !ok {
+				continue
+			}
+			if stats.sums[assignee] == nil {
+				stats.sums[assignee] = make(map[string]float64)
+			}
+			stats.sums[assignee][field.Name] += value
 		}
 	}
-	
-	fmt.Fprintln(v, "Assignee | Open | Blocked | Progress | Review | RFT | Testing | Tested | Done | Total")
+}
+
+func (stats *assigneeStats) write(v *gocui.View) {
+	header := fmt.Sprintf("Assignee | %s | Total", strings.Join(stats.columns, " | "))
+	for _, name := range stats.sumNames {
+		header += " | " + name
+	}
+	fmt.Fprintln(v, header)
 	fmt.Fprintln(v, strings.Repeat("-", 80))
 	
-	for assignee, statusMap := range stats {
-		open := statusMap["Open"]
-		blocked := statusMap["Blocked"]
-		progress := statusMap["In Progress"] 
-		review := statusMap["Code Review"]
-		rft := statusMap["Ready for Test"]
-		testing := statusMap["In Testing"]
-		tested := statusMap["Tested"]
-		done := statusMap["Done"]
-		total := statusMap["Total"]
-		fmt.Fprintf(v, "%s | %d | %d | %d | %d | %d | %d | %d | %d | %d\n", 
-			assignee, open, blocked, progress, review, rft, testing, tested, done, total)
+	assignees := make([]string, 0, len(stats.counts))
+	for assignee := range stats.counts {
+		assignees = append(assignees, assignee)
+	}
+	sort.Strings(assignees)
+	
+	for _, assignee := range assignees {
+		line := assignee
+		for _, column := range append(stats.columns, "Total") {
+			line += fmt.Sprintf(" | %d", stats.counts[assignee][column])
+		}
+		for _, name := range stats.sumNames {
+			line += fmt.Sprintf(" | %g", stats.sums[assignee][name])
+		}
+		fmt.Fprintln(v, line)
 	}
 }
 
-func (app *TUIApp) updateGlobalSummaryView(v *gocui.View) {
+func (app *TUIApp) updateSummaryView(v *gocui.View, boardID string, issues []jira.Issue) {
 	v.Clear()
 	
-	globalStats := make(map[string]map[string]int)
+	if len(issues) == 0 {
+		fmt.Fprintln(v, "No issues to display")
+		return
+	}
 	
-	// Aggregate data from all boards
-	for _, issues := range app.boardData {
-		for _, issue := range issues {
-			assignee := "Unassigned"
-			if issue.Fields.Assignee != nil {
-				assignee = issue.Fields.Assignee.Name
-			}
-			
-			if globalStats[assignee] == nil {
-				globalStats[assignee] = make(map[string]int)
-			}
-			
-			mappedStatus := app.mapStatusToGroup(issue.Fields.Status.Name)
-			// Include all statuses except Closed
-			if mappedStatus != "Closed" {
-				globalStats[assignee][mappedStatus]++
-				globalStats[assignee]["Total"]++
-			}
+	var stats assigneeStats
+	app.addBoardStats(&stats, boardID, issues)
+	stats.write(v)
+}
+
+func (app *TUIApp) updateGlobalSummaryView(v *gocui.View) {
+	v.Clear()
+	
+	// Aggregate data from all boards; query tabs repeat their issues
+	var stats assigneeStats
+	for _, board := range app.config.Boards {
+		if board.JQL != "" {
+			continue
 		}
+		app.addBoardStats(&stats, board.ID, app.boardData[board.ID])
 	}
 	
 	fmt.Fprintln(v, "Global Statistics Across All Boards")
 	fmt.Fprintln(v, "")
-	fmt.Fprintln(v, "Assignee | Open | Blocked | Progress | Review | RFT | Testing | Tested | Done | Total")
-	fmt.Fprintln(v, strings.Repeat("-", 80))
-	
-	for assignee, statusMap := range globalStats {
-		open := statusMap["Open"]
-		blocked := statusMap["Blocked"]
-		progress := statusMap["In Progress"] 
-		review := statusMap["Code Review"]
-		rft := statusMap["Ready for Test"]
-		testing := statusMap["In Testing"]
-		tested := statusMap["Tested"]
-		done := statusMap["Done"]
-		total := statusMap["Total"]
-		fmt.Fprintf(v, "%s | %d | %d | %d | %d | %d | %d | %d | %d | %d\n", 
-			assignee, open, blocked, progress, review, rft, testing, tested, done, total)
-	}
+	stats.write(v)
 }
 
 func (app *TUIApp) updateSprintChangelog(v *gocui.View, issues []jira.Issue) {
@@ -721,7 +1264,7 @@ func (app *TUIApp) switchBoard(boardIndex int) error {
 	if boardIndex == len(app.config.Boards) {
 		app.currentBoard = boardIndex
 		// Force UI update
-		app.gui.Update(func(g *gocui.Gui) error {
+		app.update(func(g *gocui.Gui) error {
 			return nil
 		})
 		return nil
@@ -732,16 +1275,33 @@ func (app *TUIApp) switchBoard(boardIndex int) error {
 		app.boardSwitchTime = time.Now() // Record when user switched to this board
 		board := app.config.Boards[boardIndex]
 		
-		app.jiraClient.SetBoardID(board.ID)
-		
 		// Start timer to turn red changes white after 1 minute on current board
 		go app.startBoardViewTimer(board.ID)
 		
+		// Cancel the refresh of the previously selected board - its result is stale
+		app.mutex.Lock()
+		if app.switchCancel != nil {
+			app.switchCancel()
+		}
+		ctx, cancel := context.WithCancel(app.ctx)
+		app.switchCancel = cancel
+		app.mutex.Unlock()
+		
 		// Force refresh data for the new board
-		go app.refreshBoardData(board.ID)
+		go func() {
+			defer cancel()
+			app.refreshBoardData(ctx, board.ID)
+		}()
 		
-		// Force UI update immediately
-		app.gui.Update(func(g *gocui.Gui) error {
+		// Start the new board at the top of every column; the views are kept
+		// between frames and would otherwise point at the old board's cards
+		app.update(func(g *gocui.Gui) error {
+			for _, v := range g.Views() {
+				if strings.HasPrefix(v.Name(), "status_") {
+					v.SetCursor(0, 0)
+					v.SetOrigin(0, 0)
+				}
+			}
 			return nil
 		})
 	}
@@ -753,53 +1313,159 @@ func (app *TUIApp) startBoardViewTimer(boardID string) {
 	// No need for board-specific timer
 }
 
+// refresh reloads every board on request, retrying boards reported missing
+// and credentials Jira rejected before.
 func (app *TUIApp) refresh(g *gocui.Gui, v *gocui.View) error {
-	go app.refreshAllData()
+	app.mutex.Lock()
+	app.missingBoards = make(map[string]bool)
+	app.authFailed = false
+	app.mutex.Unlock()
+	
+	go app.refreshAllData(app.ctx)
 	return nil
 }
 
-func (app *TUIApp) refreshAllData() {
-	for _, board := range app.config.Boards {
-		app.refreshBoardData(board.ID)
+// update runs f on the gocui main loop. Without a terminal, as when the
+// board logic runs on its own from newTUIApp, there is nothing to redraw and
+// f is dropped.
+func (app *TUIApp) update(f func(*gocui.Gui) error) {
+	if app.gui != nil {
+		app.gui.Update(f)
 	}
 }
 
-func (app *TUIApp) refreshBoardData(boardID string) {
-	app.jiraClient.SetBoardID(boardID)
+func (app *TUIApp) refreshAllData(ctx context.Context) {
+	for _, board := range app.boardList() {
+		if ctx.Err() != nil {
+			return
+		}
+		app.refreshBoardData(ctx, board.ID)
+		
+		// Every further request would be rejected too and count towards
+		// locking the account
+		app.mutex.Lock()
+		authFailed := app.authFailed
+		app.mutex.Unlock()
+		if authFailed {
+			return
+		}
+	}
+}
+
+func (app *TUIApp) refreshBoardData(ctx context.Context, boardID string) {
+	app.mutex.Lock()
+	skip := app.missingBoards[boardID] || app.authFailed || time.Now().Before(app.backoffUntil)
+	app.mutex.Unlock()
+	if skip {
+		return
+	}
 	
-	sprints, err := app.jiraClient.GetAllActiveSprints()
-	if err != nil {
+	// The query tab may have been closed meanwhile
+	board, ok := app.boardConfig(boardID)
+	if !ok {
 		return
 	}
 	
+	var sprints []jira.Sprint
 	var allIssues []jira.Issue
-	for _, sprint := range sprints {
-		issues, err := app.jiraClient.GetSprintIssuesViaJQL(sprint.ID)
+	issueSprints := make(map[string]int)
+	sprintID := 0 // Sprint picked in the sprint browser
+	failed := false
+	if board.JQL != "" {
+		// Query tabs show the search result instead of active sprints
+		issues, err := app.jiraClient.SearchContext(ctx, board.JQL)
 		if err != nil {
-			continue
+			app.handleBoardError(ctx, boardID, err)
+			return
+		}
+		allIssues = issues
+	} else if details, err := app.getBoardDetails(ctx, boardID); err != nil {
+		// Only the board itself missing means it was deleted; a missing
+		// sprint or configuration is an ordinary failure
+		if jira.IsNotFound(err) && ctx.Err() == nil {
+			app.mutex.Lock()
+			app.missingBoards[boardID] = true
+			app.mutex.Unlock()
+		}
+		app.handleBoardError(ctx, boardID, err)
+		return
+	} else if details.Type == "kanban" {
+		// Kanban boards have no sprints, Jira rejects the sprint endpoints
+		allIssues, err = app.fetchKanbanIssues(ctx, boardID)
+		if err != nil {
+			app.handleBoardError(ctx, boardID, err)
+			return
 		}
-		allIssues = append(allIssues, issues...)
+	} else {
+		sprints, sprintID, err = app.fetchBoardSprints(ctx, boardID)
+		if err != nil {
+			app.handleBoardError(ctx, boardID, err)
+			return
+		}
+		
+		for _, sprint := range sprints {
+			issues, err := app.jiraClient.GetSprintIssuesViaJQLContext(ctx, sprint.ID)
+			if err != nil {
+				app.handleBoardError(ctx, boardID, err)
+				failed = true
+				continue
+			}
+			for _, issue := range issues {
+				issueSprints[issue.Key] = sprint.ID
+			}
+			allIssues = append(allIssues, issues...)
+		}
+	}
+	
+	if board.JQL == "" {
+		app.loadBoardWorkflow(ctx, boardID)
+		app.loadBacklog(ctx, boardID)
+	}
+	if app.config.NeedsFieldLookup() {
+		app.loadFields(ctx)
+	}
+	app.loadEpics(ctx, allIssues)
+	
+	// Drop results of a cancelled refresh - they may be incomplete
+	if ctx.Err() != nil {
+		return
 	}
 	
 	app.mutex.Lock()
+	// Another sprint was picked or the query tab closed meanwhile
+	if app.appState.SelectedSprint(boardID) != sprintID || !app.hasBoard(boardID) {
+		app.mutex.Unlock()
+		return
+	}
+	if !failed && app.boardErrors[boardID] != nil {
+		delete(app.boardErrors, boardID)
+		if len(app.boardErrors) == 0 {
+			app.statusMessage = ""
+		}
+	}
 	app.boardData[boardID] = allIssues
+	app.boardSprints[boardID] = sprints
+	app.issueSprints[boardID] = issueSprints
 	app.lastUpdate = time.Now()
 	
-	// Detect changes and update state
-	hasNewChanges := app.detectAndStoreChanges(boardID, allIssues)
+	// Detect changes and update state; past and future sprints are
+	// browsed, not watched
+	hasNewChanges := false
+	if !app.isReadOnly(boardID) {
+		hasNewChanges = app.detectAndStoreChanges(boardID, allIssues)
+	}
 	
 	// Auto-switch to board with new changes
 	if hasNewChanges && app.autoSwitchEnabled {
 		go app.switchToBoardWithChanges(boardID)
 	}
 	
-	app.mutex.Unlock()
-	
-	// Save state
+	// Saved under the mutex: background goroutines write the state maps
 	app.appState.SaveState(app.stateFile)
+	app.mutex.Unlock()
 	
 	// Force UI update with complete redraw
-	app.gui.Update(func(g *gocui.Gui) error {
+	app.update(func(g *gocui.Gui) error {
 		// Force redraw of all views
 		for _, viewName := range app.activeViews {
 			if v, err := g.View(viewName); err == nil {
@@ -814,8 +1480,99 @@ func (app *TUIApp) refreshBoardData(boardID string) {
 	})
 }
 
+// handleBoardError records a failed board refresh and reacts to the kind of
+// failure: re-prompt on bad credentials, report deleted boards, back off
+// when rate limited.
+func (app *TUIApp) handleBoardError(ctx context.Context, boardID string, err error) {
+	// Cancellation is not a failure of the board
+	if ctx.Err() != nil {
+		return
+	}
+	
+	app.mutex.Lock()
+	app.boardErrors[boardID] = err
+	
+	switch {
+	case jira.IsUnauthorized(err):
+		app.authFailed = true
+		app.statusMessage = "Jira rejected the credentials"
+		app.update(func(g *gocui.Gui) error {
+			app.promptCredentials()
+			return nil
+		})
+	case app.missingBoards[boardID]:
+		app.statusMessage = fmt.Sprintf("Board %s not found in Jira, skipping it", boardID)
+	case jira.IsRateLimited(err):
+		wait := time.Minute
+		if apiErr, ok := jira.AsAPIError(err); ok && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		app.backoffUntil = time.Now().Add(wait)
+		app.statusMessage = fmt.Sprintf("Rate limited by Jira, pausing refresh until %s", app.backoffUntil.Format("15:04:05"))
+	default:
+		app.statusMessage = fmt.Sprintf("Refresh of board %s failed", boardID)
+	}
+	app.mutex.Unlock()
+	
+	app.update(func(g *gocui.Gui) error {
+		return nil
+	})
+}
+
+// promptCredentials asks for new credentials of the configured auth type
+// after Jira answered 401, then retries all boards. Runs on the gocui main loop.
+func (app *TUIApp) promptCredentials() {
+	if app.prompt != nil {
+		return
+	}
+	
+	if app.creds.authType == "bearer" {
+		app.showPrompt("Jira Personal Access Token", "", true, func(token string) error {
+			creds := app.creds
+			creds.token = token
+			return app.applyCredentials(creds)
+		})
+		return
+	}
+	
+	app.showPrompt("Jira username", app.creds.username, false, func(username string) error {
+		app.showPrompt(fmt.Sprintf("Password for %s", username), "", true, func(password string) error {
+			creds := app.creds
+			creds.username = username
+			creds.password = password
+			return app.applyCredentials(creds)
+		})
+		return nil
+	})
+}
+
+func (app *TUIApp) applyCredentials(creds credentials) error {
+	auth, err := creds.authenticator(app.config.JiraURL)
+	if err != nil {
+		app.mutex.Lock()
+		app.statusMessage = err.Error()
+		app.mutex.Unlock()
+		return nil
+	}
+	
+	app.mutex.Lock()
+	app.creds = creds
+	app.jiraClient.SetAuthenticator(auth)
+	app.myself = nil // May be a different account now
+	app.boardErrors = make(map[string]error)
+	app.missingBoards = make(map[string]bool) // The old account may not have seen them
+	app.authFailed = false
+	app.statusMessage = ""
+	app.mutex.Unlock()
+	
+	go app.refreshAllData(app.ctx)
+	return nil
+}
+
 func (app *TUIApp) detectAndStoreChanges(boardID string, issues []jira.Issue) bool {
 	hasNewChanges := false
+	// Only mark changes when the board was seen before, not on its first run
+	hasPreviousState := len(app.appState.GetBoardState(boardID).Issues) > 0
 	
 	for _, issue := range issues {
 		assignee := "Unassigned"
@@ -828,9 +1585,7 @@ func (app *TUIApp) detectAndStoreChanges(boardID string, issues []jira.Issue) bo
 		
 		// Check if issue has changed since last run (only compare if we have previous state)
 		if app.appState.HasIssueChanged(boardID, issue.Key, status, assignee) {
-			// Only mark as new change if this is not the first run
-			boardState := app.appState.GetBoardState(boardID)
-			if len(boardState.Issues) > 0 { // We have previous state
+			if hasPreviousState {
 				hasNewChanges = true
 				
 				
@@ -909,7 +1664,7 @@ func (app *TUIApp) cleanupChangeQueue() {
 	
 	// Update UI if changes were made
 	if needsUpdate {
-		app.gui.Update(func(g *gocui.Gui) error {
+		app.update(func(g *gocui.Gui) error {
 			return nil
 		})
 	}
@@ -917,10 +1672,22 @@ func (app *TUIApp) cleanupChangeQueue() {
 
 func (app *TUIApp) switchToBoardWithChanges(boardID string) {
 	// Find board index by ID
-	for i, board := range app.config.Boards {
+	for _, board := range app.boardList() {
 		if board.ID == boardID {
 			time.Sleep(1 * time.Second) // Small delay before switching
-			app.switchBoard(i)
+			app.update(func(g *gocui.Gui) error {
+				// Don't pull the board away while the user is in a dialog
+				if app.modalOpen() {
+					return nil
+				}
+				// Looked up again, a query tab may have been closed meanwhile
+				for i, board := range app.config.Boards {
+					if board.ID == boardID {
+						return app.switchBoard(i)
+					}
+				}
+				return nil
+			})
 			break
 		}
 	}
@@ -986,7 +1753,8 @@ func (app *TUIApp) moveToPreviousView(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (app *TUIApp) moveToNextView(g *gocui.Gui, v *gocui.View) error {
-	if len(app.activeViews) == 0 {
+	// Keep focus inside an open overlay
+	if len(app.activeViews) == 0 || app.modalOpen() {
 		return nil
 	}
 	
@@ -1087,32 +1855,44 @@ func (app *TUIApp) goToBottom(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (app *TUIApp) quit(g *gocui.Gui, v *gocui.View) error {
+	// Abort in-flight requests so exit does not wait for HTTP timeouts
+	app.cancel()
+	
 	// Save state before quitting
+	app.mutex.Lock()
 	app.appState.SaveState(app.stateFile)
+	app.mutex.Unlock()
 	return gocui.ErrQuit
 }
 
 func (app *TUIApp) Run() error {
 	defer func() {
+		app.cancel()
 		app.gui.Close()
 		// Save state on exit
+		app.mutex.Lock()
 		app.appState.SaveState(app.stateFile)
+		app.mutex.Unlock()
 	}()
 	
 	// Set initial board
 	if len(app.config.Boards) > 0 {
 		app.currentBoard = 0
-		app.jiraClient.SetBoardID(app.config.Boards[0].ID)
 	}
 	
 	// Initial data load
-	go app.refreshAllData()
+	go app.refreshAllData(app.ctx)
 	
 	// Auto-refresh timer
 	ticker := time.NewTicker(time.Duration(app.config.RefreshInterval) * time.Second)
 	go func() {
-		for range ticker.C {
-			app.refreshAllData()
+		for {
+			select {
+			case <-app.ctx.Done():
+				return
+			case <-ticker.C:
+				app.refreshAllData(app.ctx)
+			}
 		}
 	}()
 	defer ticker.Stop()
@@ -1120,8 +1900,13 @@ func (app *TUIApp) Run() error {
 	// Timer for cleaning up change notifications (every 30 seconds)
 	cleanupTicker := time.NewTicker(30 * time.Second)
 	go func() {
-		for range cleanupTicker.C {
-			app.cleanupChangeQueue()
+		for {
+			select {
+			case <-app.ctx.Done():
+				return
+			case <-cleanupTicker.C:
+				app.cleanupChangeQueue()
+			}
 		}
 	}()
 	defer cleanupTicker.Stop()
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"jira-boards-tui/pkg/config"
+	"jira-boards-tui/pkg/jira"
+	"jira-boards-tui/pkg/jira/jiratest"
+	"jira-boards-tui/pkg/state"
+)
+
+// newTestApp returns an app on the fake without a terminal, as the board
+// logic runs between redraws.
+func newTestApp(t *testing.T, cfg *config.Config, store *jiratest.Store) *TUIApp {
+	t.Helper()
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	appState, err := state.LoadState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := newTUIApp(cfg, jiratest.NewFake(store), appState, stateFile)
+	app.autoSwitchEnabled = false
+	t.Cleanup(app.cancel)
+	return app
+}
+
+func testIssue(key, status string) jira.Issue {
+	return jira.Issue{Key: key, Fields: jira.IssueFields{
+		Summary: "Issue " + key,
+		Status:  jira.Status{Name: status},
+	}}
+}
+
+func TestDetectAndStoreChanges(t *testing.T) {
+	app := newTestApp(t, config.Default(), jiratest.NewStore(jiratest.Fixtures{}))
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	issues := []jira.Issue{testIssue("DEV-1", "To Do"), testIssue("DEV-2", "In Progress")}
+	if app.detectAndStoreChanges("1", issues) || len(app.changeQueue) != 0 {
+		t.Fatalf("first run on a board queued changes %v, want none", app.changeQueue)
+	}
+	if app.detectAndStoreChanges("1", issues) {
+		t.Fatal("detectAndStoreChanges reported changes without any")
+	}
+
+	issues[1] = testIssue("DEV-2", "Done")
+	issues = append(issues, testIssue("DEV-3", "To Do"))
+	if !app.detectAndStoreChanges("1", issues) {
+		t.Fatal("detectAndStoreChanges missed a status change and a new issue")
+	}
+	var keys []string
+	for _, change := range app.changeQueue {
+		if change.BoardID != "1" || !change.IsNew {
+			t.Errorf("change %+v is not a new change on board 1", change)
+		}
+		keys = append(keys, change.IssueKey)
+	}
+	if len(keys) != 2 || keys[0] != "DEV-2" || keys[1] != "DEV-3" {
+		t.Errorf("changed issues are %v, want DEV-2 and DEV-3", keys)
+	}
+
+	// Other boards keep their own first run
+	if app.detectAndStoreChanges("2", issues) {
+		t.Error("first run on another board reported changes")
+	}
+}
+
+func TestRefreshBoardDataWithoutTerminal(t *testing.T) {
+	store := jiratest.NewStore(jiratest.Fixtures{
+		Sprints: map[string][]jira.Sprint{"1": {{ID: 10, Name: "Sprint 1", State: "active"}}},
+		Issues: map[int][]jira.Issue{10: {
+			testIssue("DEV-1", "To Do"),
+			testIssue("DEV-2", "In Progress"),
+		}},
+	})
+	cfg := config.Default()
+	cfg.Boards = []config.Board{{ID: "1", Name: "Board"}, {ID: "404", Name: "Deleted"}}
+	app := newTestApp(t, cfg, store)
+
+	app.refreshBoardData(context.Background(), "1")
+	if got := len(app.boardData["1"]); got != 2 {
+		t.Fatalf("board has %d issues after the first refresh, want 2", got)
+	}
+	if len(app.changeQueue) != 0 {
+		t.Fatalf("first refresh queued changes %v, want none", app.changeQueue)
+	}
+
+	store.UpdateIssue("DEV-2", func(issue *jira.Issue) {
+		issue.Fields.Status = jira.Status{Name: "Done"}
+	})
+	app.refreshBoardData(context.Background(), "1")
+	if len(app.changeQueue) != 1 || app.changeQueue[0].IssueKey != "DEV-2" {
+		t.Fatalf("change queue is %v, want DEV-2", app.changeQueue)
+	}
+	app.cleanupChangeQueue()
+
+	// Failures are reported without a terminal as well
+	app.refreshBoardData(context.Background(), "404")
+	if app.boardErrors["404"] == nil {
+		t.Error("refreshing a missing board recorded no error")
+	}
+}
+
+// boardColumnsApp returns an app with board 1 on the default workflow and
+// board 2 taking its columns from the Jira board configuration.
+func boardColumnsApp(t *testing.T) *TUIApp {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Boards = []config.Board{
+		{ID: "1", Name: "Configured"},
+		{ID: "2", Name: "From Jira", Workflow: &config.Workflow{FromBoard: true}},
+	}
+	app := newTestApp(t, cfg, jiratest.NewStore(jiratest.Fixtures{}))
+	app.boardWorkflows["2"] = &config.Workflow{
+		FromBoard: true,
+		Columns:   []string{"Todo", "Doing", "Done"},
+		StatusMapping: []config.StatusMapping{
+			{Column: "Todo", StatusIDs: []string{"1"}},
+			{Column: "Doing", StatusIDs: []string{"3"}},
+			{Column: "Done", StatusIDs: []string{"6"}},
+		},
+	}
+	return app
+}
+
+func TestMapStatusToGroupFromBoard(t *testing.T) {
+	app := boardColumnsApp(t)
+	done := &jira.StatusCategory{Key: "done", Name: "Done"}
+
+	tests := []struct {
+		name   string
+		status jira.Status
+		want   string
+	}{
+		{name: "board column", status: jira.Status{ID: "3", Name: "In Progress"}, want: "Doing"},
+		{name: "board column for closed", status: jira.Status{ID: "6", Name: "Closed"}, want: "Done"},
+		{name: "left out of board columns", status: jira.Status{ID: "9", Name: "Parked", StatusCategory: done}, want: ""},
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	for _, tt := range tests {
+		if got := app.mapStatusToGroup("2", tt.status); got != tt.want {
+			t.Errorf("%s: mapStatusToGroup(%q) = %q, want %q", tt.name, tt.status.Name, got, tt.want)
+		}
+	}
+}
+
+func TestGetAllStatuses(t *testing.T) {
+	app := boardColumnsApp(t)
+	issues := []jira.Issue{
+		testIssue("DEV-1", "Resolved"),
+		testIssue("DEV-2", "To Do"),
+		testIssue("DEV-3", "Review"),
+		testIssue("DEV-4", "Closed"),
+		testIssue("DEV-5", "Parked"),
+	}
+
+	tests := []struct {
+		name    string
+		boardID string
+		issues  []jira.Issue
+		want    []string
+	}{
+		{name: "configured columns in use, in order", boardID: "1", issues: issues, want: []string{"Open", "Code Review", "Done"}},
+		{name: "no issues", boardID: "1", issues: nil, want: nil},
+		{name: "board columns even when empty", boardID: "2", issues: nil, want: []string{"Todo", "Doing", "Done"}},
+		{name: "board columns only", boardID: "2", issues: issues, want: []string{"Todo", "Doing", "Done"}},
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	for _, tt := range tests {
+		if got := app.getAllStatuses(tt.boardID, tt.issues); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: getAllStatuses(%s) = %q, want %q", tt.name, tt.boardID, got, tt.want)
+		}
+	}
+}
+
+func TestMapStatusToGroup(t *testing.T) {
+	done := &jira.StatusCategory{Key: "done", Name: "Done"}
+	inProgress := &jira.StatusCategory{Key: "indeterminate", Name: "In Progress"}
+
+	cfg := config.Default()
+	cfg.Workflow.StatusMapping = append(cfg.Workflow.StatusMapping,
+		config.StatusMapping{Column: "Blocked", StatusIDs: []string{"10042"}},
+		config.StatusMapping{Column: "In Testing", Statuses: []string{"Ready for *"}},
+	)
+	cfg.Workflow.CategoryColumns = map[string]string{"new": "Open"}
+	app := newTestApp(t, cfg, jiratest.NewStore(jiratest.Fixtures{}))
+
+	tests := []struct {
+		name   string
+		status jira.Status
+		want   string
+	}{
+		{name: "by name", status: jira.Status{Name: "In Development"}, want: "In Progress"},
+		{name: "by ID", status: jira.Status{ID: "10042", Name: "Impeded"}, want: "Blocked"},
+		{name: "by pattern", status: jira.Status{Name: "Ready for UAT"}, want: "In Testing"},
+		{name: "hidden", status: jira.Status{Name: "Closed", StatusCategory: done}, want: ""},
+		{name: "configured category column", status: jira.Status{Name: "Triage", StatusCategory: &jira.StatusCategory{Key: "new", Name: "To Do"}}, want: "Open"},
+		{name: "column named like the category", status: jira.Status{Name: "Designing", StatusCategory: inProgress}, want: "In Progress"},
+		{name: "done category", status: jira.Status{Name: "Shipped", StatusCategory: done}, want: "Done"},
+		{name: "unknown", status: jira.Status{Name: "Parked"}, want: "Parked"},
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	for _, tt := range tests {
+		if got := app.mapStatusToGroup("1", tt.status); got != tt.want {
+			t.Errorf("%s: mapStatusToGroup(%q) = %q, want %q", tt.name, tt.status.Name, got, tt.want)
+		}
+	}
+}